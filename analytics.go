@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Athlete analytics across every recorded throw, for training as well as
+// competition. Lateral deviation uses the sector frame from heatmap.go.
+
+const (
+	defaultMovingAverageWindow = 6
+	competitionImplementsFile  = "competition_implements.json"
+)
+
+// CompetitionImplements names the implement each event is contested with,
+// so training throws with it count towards bests. Athletes who compete with
+// a different weight, e.g. by age group, have their own entry.
+type CompetitionImplements struct {
+	ByCircleType map[string]string            `json:"byCircleType"`
+	ByAthlete    map[string]map[string]string `json:"byAthlete"` // Athlete ID -> circle type -> implement
+}
+
+// implement returns the athlete's competition implement in the circle type,
+// or "" if none is set
+func (c CompetitionImplements) implement(athleteID, circleType string) string {
+	if implement, ok := c.ByAthlete[athleteID][circleType]; ok {
+		return implement
+	}
+	return c.ByCircleType[circleType]
+}
+
+// sameImplement compares implement labels ignoring case and spacing, so
+// "7.26 kg" matches "7.26kg"
+func sameImplement(a, b string) bool {
+	normalise := func(s string) string { return strings.ToLower(strings.Join(strings.Fields(s), "")) }
+	return normalise(a) == normalise(b)
+}
+
+func loadCompetitionImplements() CompetitionImplements {
+	var implements CompetitionImplements
+	if err := loadJSONConfig(competitionImplementsFile, &implements); err != nil {
+		log.Printf("Could not load competition implements: %v", err)
+	}
+	return implements
+}
+
+// AnalyticsThrow is one throw in an athlete's history
+type AnalyticsThrow struct {
+	ID            string    `json:"id,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+	Distance      float64   `json:"distance"`
+	Lateral       float64   `json:"lateral"`       // Metres left (+) or right (-) of the sector centreline
+	MovingAverage float64   `json:"movingAverage"` // Mean of this and the previous window-1 throws
+	PersonalBest  bool      `json:"personalBest"`  // A new best at the time
+	SeasonBest    bool      `json:"seasonBest"`    // A new best for its calendar year
+	Implement     string    `json:"implement,omitempty"`
+	Training      bool      `json:"training,omitempty"`
+}
+
+// AnalyticsDay summarises the throws of one day
+type AnalyticsDay struct {
+	Date   string  `json:"date"` // YYYY-MM-DD, local time
+	Throws int     `json:"throws"`
+	Fouls  int     `json:"fouls"`
+	Best   float64 `json:"best"`
+	Mean   float64 `json:"mean"`
+}
+
+// AthleteAnalytics is an athlete's progression and consistency in one event
+type AthleteAnalytics struct {
+	AthleteID     string           `json:"athleteId"`
+	Name          string           `json:"name"`
+	CircleType    string           `json:"circleType"`
+	Throws        int              `json:"throws"`
+	Fouls         int              `json:"fouls"`
+	FoulRate      float64          `json:"foulRate"`            // Percentage of fouls among fouls and marks
+	Implement     string           `json:"implement,omitempty"` // Competition implement training throws need to count towards bests
+	PersonalBest  float64          `json:"personalBest"`
+	SeasonBest    float64          `json:"seasonBest"` // Best in the latest throw's calendar year
+	Mean          float64          `json:"mean"`
+	StdDev        float64          `json:"stdDev"`
+	LateralMean   float64          `json:"lateralMean"`   // Average side bias
+	LateralStdDev float64          `json:"lateralStdDev"` // Spread across the sector
+	TrendPerWeek  float64          `json:"trendPerWeek"`  // Least-squares slope of distance over time, m/week
+	Window        int              `json:"window"`
+	History       []AnalyticsThrow `json:"history"`
+	Days          []AnalyticsDay   `json:"days"`
+}
+
+func meanStdDev(values []float64) (mean, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if len(values) < 2 {
+		return mean, 0
+	}
+	for _, v := range values {
+		stdDev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stdDev / float64(len(values)-1))
+}
+
+// trendPerWeek fits distance against time by least squares
+func trendPerWeek(history []AnalyticsThrow) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+	start := history[0].Timestamp
+	var sumT, sumD, sumTT, sumTD float64
+	for _, t := range history {
+		weeks := t.Timestamp.Sub(start).Hours() / (24 * 7)
+		sumT += weeks
+		sumD += t.Distance
+		sumTT += weeks * weeks
+		sumTD += weeks * t.Distance
+	}
+	n := float64(len(history))
+	denominator := n*sumTT - sumT*sumT
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumTD - sumT*sumD) / denominator
+}
+
+// athleteNameLocked finds the athlete in any start list. Caller holds
+// stateMux.
+func (a *App) athleteNameLocked(bib string) string {
+	for _, list := range a.startLists {
+		for _, entry := range list.Entries {
+			if entry.Bib == bib {
+				return entry.DisplayName()
+			}
+		}
+	}
+	return ""
+}
+
+// athleteAnalyticsLocked computes analytics from every throw and foul the
+// athlete has in the circle type. Caller holds stateMux.
+func (a *App) athleteAnalyticsLocked(athleteID, circleType string, window int) *AthleteAnalytics {
+	if window <= 0 {
+		window = defaultMovingAverageWindow
+	}
+	result := &AthleteAnalytics{
+		AthleteID:  athleteID,
+		Name:       a.athleteNameLocked(athleteID),
+		CircleType: circleType,
+		Implement:  a.competitionImplements.implement(athleteID, circleType),
+		Window:     window,
+	}
+	centreline := a.throwCentrelineLocked()
+	for _, coord := range a.throwCoordinates {
+		if coord.AthleteID != athleteID || coord.CircleType != circleType {
+			continue
+		}
+		p := heatmapPoint(coord, FrameSector, centreline(coord))
+		result.History = append(result.History, AnalyticsThrow{
+			ID:        coord.ID,
+			Timestamp: coord.Timestamp,
+			Distance:  truncateMark(coord.Distance),
+			Lateral:   p.Y,
+			Implement: coord.Implement,
+			Training:  coord.Training,
+		})
+	}
+	var foulTimes []time.Time
+	for _, record := range a.attempts {
+		if record.AthleteID == athleteID && record.CircleType == circleType && record.Kind == AttemptFoul {
+			foulTimes = append(foulTimes, record.Timestamp)
+		}
+	}
+	sort.Slice(result.History, func(i, j int) bool { return result.History[i].Timestamp.Before(result.History[j].Timestamp) })
+
+	result.Throws, result.Fouls = len(result.History), len(foulTimes)
+	if total := result.Throws + result.Fouls; total > 0 {
+		result.FoulRate = 100 * float64(result.Fouls) / float64(total)
+	}
+
+	distances := make([]float64, len(result.History))
+	laterals := make([]float64, len(result.History))
+	seasonBests := make(map[int]float64)
+	days := make(map[string]*AnalyticsDay)
+	var dayOrder []string
+	day := func(t time.Time) *AnalyticsDay {
+		key := t.Local().Format("2006-01-02")
+		d, ok := days[key]
+		if !ok {
+			d = &AnalyticsDay{Date: key}
+			days[key] = d
+			dayOrder = append(dayOrder, key)
+		}
+		return d
+	}
+	sum := 0.0
+	for i := range result.History {
+		t := &result.History[i]
+		distances[i], laterals[i] = t.Distance, t.Lateral
+
+		sum += t.Distance
+		if i >= window {
+			sum -= result.History[i-window].Distance
+		}
+		t.MovingAverage = sum / float64(min(i+1, window))
+
+		// Competition throws set bests, training throws only with the
+		// competition implement
+		counts := !t.Training || (result.Implement != "" && sameImplement(t.Implement, result.Implement))
+		year := t.Timestamp.Local().Year()
+		if counts && t.Distance > result.PersonalBest {
+			result.PersonalBest, t.PersonalBest = t.Distance, true
+		}
+		if counts && t.Distance > seasonBests[year] {
+			seasonBests[year], t.SeasonBest = t.Distance, true
+		}
+
+		d := day(t.Timestamp)
+		d.Throws++
+		d.Best = math.Max(d.Best, t.Distance)
+		d.Mean += t.Distance
+	}
+	for _, foul := range foulTimes {
+		day(foul).Fouls++
+	}
+	if n := len(result.History); n > 0 {
+		result.SeasonBest = seasonBests[result.History[n-1].Timestamp.Local().Year()]
+	}
+	result.Mean, result.StdDev = meanStdDev(distances)
+	result.LateralMean, result.LateralStdDev = meanStdDev(laterals)
+	result.TrendPerWeek = trendPerWeek(result.History)
+
+	sort.Strings(dayOrder)
+	for _, key := range dayOrder {
+		d := days[key]
+		if d.Throws > 0 {
+			d.Mean /= float64(d.Throws)
+		}
+		result.Days = append(result.Days, *d)
+	}
+	return result
+}
+
+// --- Wails Bindable Analytics Functions ---
+
+// GetAthleteAnalytics returns an athlete's progression and consistency in
+// one circle type. window is the moving average length (default 6).
+func (a *App) GetAthleteAnalytics(athleteID string, circleType string, window int) (*AthleteAnalytics, error) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	result := a.athleteAnalyticsLocked(athleteID, circleType, window)
+	if result.Throws == 0 && result.Fouls == 0 {
+		return nil, fmt.Errorf("no throws for athlete '%s' in %s", athleteID, circleType)
+	}
+	return result, nil
+}
+
+// GetAthletesAnalytics summarises every athlete with throws in the circle
+// type, best first. History and days are left out.
+func (a *App) GetAthletesAnalytics(circleType string) []AthleteAnalytics {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	athletes := make(map[string]bool)
+	for _, coord := range a.throwCoordinates {
+		if coord.CircleType == circleType && coord.AthleteID != "" {
+			athletes[coord.AthleteID] = true
+		}
+	}
+	summaries := make([]AthleteAnalytics, 0, len(athletes))
+	for athleteID := range athletes {
+		summary := *a.athleteAnalyticsLocked(athleteID, circleType, 0)
+		summary.History, summary.Days = nil, nil
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].PersonalBest != summaries[j].PersonalBest {
+			return summaries[i].PersonalBest > summaries[j].PersonalBest
+		}
+		return summaries[i].AthleteID < summaries[j].AthleteID
+	})
+	return summaries
+}
+
+// GetCompetitionImplements returns the implements that training throws need
+// to count towards bests
+func (a *App) GetCompetitionImplements() CompetitionImplements {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.competitionImplements
+}
+
+// SetCompetitionImplement sets the implement an event is contested with,
+// e.g. "7.26kg" for SHOT, for every athlete or, given athleteID, for one.
+// An empty implement clears the setting.
+func (a *App) SetCompetitionImplement(circleType string, athleteID string, implement string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	implement = strings.TrimSpace(implement)
+	c := &a.competitionImplements
+	if athleteID == "" {
+		if c.ByCircleType == nil {
+			c.ByCircleType = make(map[string]string)
+		}
+		if implement == "" {
+			delete(c.ByCircleType, circleType)
+		} else {
+			c.ByCircleType[circleType] = implement
+		}
+	} else {
+		if implement == "" {
+			delete(c.ByAthlete[athleteID], circleType)
+			if len(c.ByAthlete[athleteID]) == 0 {
+				delete(c.ByAthlete, athleteID)
+			}
+		} else {
+			if c.ByAthlete == nil {
+				c.ByAthlete = make(map[string]map[string]string)
+			}
+			if c.ByAthlete[athleteID] == nil {
+				c.ByAthlete[athleteID] = make(map[string]string)
+			}
+			c.ByAthlete[athleteID][circleType] = implement
+		}
+	}
+	if err := saveJSONConfig(competitionImplementsFile, a.competitionImplements); err != nil {
+		return err
+	}
+	log.Printf("Competition implement for %s (athlete '%s'): '%s'", circleType, athleteID, implement)
+	return nil
+}
+
+// ExportAthleteAnalyticsCSV writes an athlete's throw history with moving
+// average, lateral deviation and best markers, one row per throw
+func (a *App) ExportAthleteAnalyticsCSV(athleteID string, circleType string, window int) (string, error) {
+	result, err := a.GetAthleteAnalytics(athleteID, circleType, window)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"Timestamp", "Distance", "Lateral", fmt.Sprintf("MovingAverage%d", result.Window), "PersonalBest", "SeasonBest"})
+	for _, t := range result.History {
+		w.Write([]string{
+			t.Timestamp.Format(time.RFC3339),
+			formatMark(t.Distance),
+			strconv.FormatFloat(t.Lateral, 'f', 2, 64),
+			strconv.FormatFloat(t.MovingAverage, 'f', 2, 64),
+			strconv.FormatBool(t.PersonalBest),
+			strconv.FormatBool(t.SeasonBest),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("could not write CSV: %w", err)
+	}
+	log.Printf("Exported analytics for athlete '%s' in %s: %d throws", athleteID, circleType, result.Throws)
+	return b.String(), nil
+}
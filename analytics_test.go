@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// addAnalyticsThrows records SHOT throws for athlete 7, a day apart
+func addAnalyticsThrows(a *App, throws ...ThrowCoordinate) {
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, coord := range throws {
+		coord.ID = strconv.Itoa(i)
+		coord.AthleteID = "7"
+		coord.CircleType = "SHOT"
+		coord.Timestamp = at.AddDate(0, 0, i)
+		a.throwCoordinates = append(a.throwCoordinates, coord)
+	}
+}
+
+func TestAnalyticsBestsUseCompetitionImplement(t *testing.T) {
+	throws := []ThrowCoordinate{
+		{Distance: 14.0},
+		{Distance: 16.5, Training: true, Implement: "6kg"},
+		{Distance: 15.0, Training: true, Implement: "7.26 kg"},
+	}
+	tests := []struct {
+		name      string
+		circle    string // Competition implement for SHOT
+		athlete   string // Competition implement for athlete 7
+		wantBest  float64
+		wantMarks []bool
+	}{
+		{"none set", "", "", 14.0, []bool{true, false, false}},
+		{"circle type", "7.26kg", "", 15.0, []bool{true, false, true}},
+		{"athlete", "7.26kg", "6kg", 16.5, []bool{true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, "SHOT")
+			addAnalyticsThrows(a, throws...)
+			if err := a.SetCompetitionImplement("SHOT", "", tt.circle); err != nil {
+				t.Fatalf("SetCompetitionImplement: %v", err)
+			}
+			if err := a.SetCompetitionImplement("SHOT", "7", tt.athlete); err != nil {
+				t.Fatalf("SetCompetitionImplement: %v", err)
+			}
+
+			result, err := a.GetAthleteAnalytics("7", "SHOT", 0)
+			if err != nil {
+				t.Fatalf("GetAthleteAnalytics: %v", err)
+			}
+			if result.PersonalBest != tt.wantBest || result.SeasonBest != tt.wantBest {
+				t.Errorf("PB %.2f SB %.2f, want %.2f", result.PersonalBest, result.SeasonBest, tt.wantBest)
+			}
+			for i, th := range result.History {
+				if th.PersonalBest != tt.wantMarks[i] {
+					t.Errorf("throw %d (%.2f %q) marked PB %v, want %v", i, th.Distance, th.Implement, th.PersonalBest, tt.wantMarks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompetitionImplementsPersist(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	if err := a.SetCompetitionImplement("SHOT", "", "7.26kg"); err != nil {
+		t.Fatalf("SetCompetitionImplement: %v", err)
+	}
+	if err := a.SetCompetitionImplement("SHOT", "7", "5kg"); err != nil {
+		t.Fatalf("SetCompetitionImplement: %v", err)
+	}
+	if err := a.SetCompetitionImplement("SHOT", "7", ""); err != nil {
+		t.Fatalf("SetCompetitionImplement: %v", err)
+	}
+
+	loaded := loadCompetitionImplements()
+	if got := loaded.implement("7", "SHOT"); got != "7.26kg" {
+		t.Errorf("athlete 7 implement = %q after clearing the override, want the SHOT default", got)
+	}
+	if len(loaded.ByAthlete) != 0 {
+		t.Errorf("cleared override left %v", loaded.ByAthlete)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -35,6 +36,14 @@ const (
 	UkaRadiusJavelinArc = 8.000  // Javelin arc radius (meters)
 )
 
+// ukaCircleRadius maps circle types to their official radius
+var ukaCircleRadius = map[string]float64{
+	"SHOT":        UkaRadiusShot,
+	"DISCUS":      UkaRadiusDiscus,
+	"HAMMER":      UkaRadiusHammer,
+	"JAVELIN_ARC": UkaRadiusJavelinArc,
+}
+
 // Tolerance constants
 const (
 	ToleranceThrowsCircleMm = 5.0  // Standard tolerance for throws circles
@@ -50,10 +59,19 @@ const (
 
 // --- Data Structures ---
 type Device struct {
-	Conn           io.ReadWriteCloser
-	ConnectionType string
-	Address        string
-	cancelListener context.CancelFunc // To stop the listener goroutine
+	Conn            io.ReadWriteCloser
+	ConnectionType  string
+	Address         string
+	cancelListener  context.CancelFunc // To stop the listener goroutine
+	driver          ScoreboardDriver   // Wire protocol, scoreboard only
+	serialSettings  SerialSettings     // Line settings, reused on reconnect
+	usbSerialNumber string             // Bound USB adapter, port name is re-resolved on reconnect
+	io              *deviceIO          // Serialised request/response access to Conn
+	// Connection health, maintained by the supervisor
+	state        string
+	lastError    string
+	lastActivity time.Time
+	reconnects   int
 }
 
 type WindReading struct {
@@ -87,6 +105,8 @@ type EDMCalibrationData struct {
 	StationCoordinates     EDMPoint                `json:"stationCoordinates"`
 	IsCentreSet            bool                    `json:"isCentreSet"`
 	EdgeVerificationResult *EdgeVerificationResult `json:"edgeVerificationResult,omitempty"`
+	SectorBearing          float64                 `json:"sectorBearing"` // Sector centreline, degrees anticlockwise from the EDM's zero
+	IsSectorSet            bool                    `json:"isSectorSet"`
 }
 
 type ParsedEDMReading struct {
@@ -97,20 +117,35 @@ type ParsedEDMReading struct {
 
 // Throw coordinate data structure
 type ThrowCoordinate struct {
-	X                float64   `json:"x"`                // X coordinate (metres from centre)
-	Y                float64   `json:"y"`                // Y coordinate (metres from centre)
-	Distance         float64   `json:"distance"`         // Calculated throw distance
-	CircleType       string    `json:"circleType"`       // SHOT, DISCUS, HAMMER, JAVELIN_ARC
-	Timestamp        time.Time `json:"timestamp"`        // When the throw was measured
-	AthleteID        string    `json:"athleteId"`        // Optional athlete identifier
-	CompetitionRound string    `json:"competitionRound"` // Optional round/session identifier
-	EDMReading       string    `json:"edmReading"`       // Raw EDM reading for reference
+	X                float64   `json:"x"`                   // X coordinate (metres from centre)
+	Y                float64   `json:"y"`                   // Y coordinate (metres from centre)
+	Distance         float64   `json:"distance"`            // Calculated throw distance
+	CircleType       string    `json:"circleType"`          // SHOT, DISCUS, HAMMER, JAVELIN_ARC
+	Timestamp        time.Time `json:"timestamp"`           // When the throw was measured
+	ID               string    `json:"id,omitempty"`        // Unique record ID, used to merge synced results
+	Origin           string    `json:"origin,omitempty"`    // Instance that recorded the throw
+	AthleteID        string    `json:"athleteId"`           // Optional athlete identifier
+	Station          string    `json:"station,omitempty"`   // EDM that measured the throw
+	Event            string    `json:"event,omitempty"`     // Start list event the station is measuring
+	Attempt          int       `json:"attempt,omitempty"`   // Attempt number, when an athlete is set
+	SessionID        string    `json:"sessionId,omitempty"` // Session the throw was measured in
+	CompetitionRound string    `json:"competitionRound"`    // Optional round/session identifier
+	Training         bool      `json:"training,omitempty"`  // Measured in a training session, kept out of results
+	Implement        string    `json:"implement,omitempty"` // Training implement, e.g. "5kg"
+	Tags             []string  `json:"tags,omitempty"`      // Drills and notes
+	EDMReading       string    `json:"edmReading"`          // Raw EDM reading for reference
 }
 
 // Session data for grouping throws
 type ThrowSession struct {
 	SessionID   string             `json:"sessionId"`
+	Name        string             `json:"name,omitempty"` // Display name, set with RenameSession
+	Station     string             `json:"station,omitempty"`
+	Origin      string             `json:"origin,omitempty"`
 	CircleType  string             `json:"circleType"`
+	Event       string             `json:"event,omitempty"`  // Station's event when the session started
+	Mode        string             `json:"mode,omitempty"`   // "training", or empty for competition
+	Roster      []string           `json:"roster,omitempty"` // Training athletes in rotation order
 	StartTime   time.Time          `json:"startTime"`
 	EndTime     *time.Time         `json:"endTime,omitempty"`
 	Coordinates []ThrowCoordinate  `json:"coordinates"`
@@ -126,6 +161,9 @@ type SessionStatistics struct {
 	MinDistance     float64 `json:"minDistance"`
 	AverageDistance float64 `json:"averageDistance"`
 	SpreadRadius    float64 `json:"spreadRadius"` // Standard deviation of landing positions
+	// Training sessions only: the same figures per athlete and per implement
+	ByAthlete   map[string]*SessionStatistics `json:"byAthlete,omitempty"`
+	ByImplement map[string]*SessionStatistics `json:"byImplement,omitempty"`
 }
 
 // Demo simulation state to maintain consistency
@@ -144,38 +182,102 @@ type App struct {
 	demoMode         bool
 	CalibrationStore map[string]*EDMCalibrationData
 	demoSim          map[string]*DemoSimulation // Per-device demo simulation
+	demoThrowHook    func(devType string)       // Tests only: runs mid-read, before a demo throw reading
+	// Per-EDM session, athlete and board, keyed like CalibrationStore
+	stations map[string]*station
+	// Scoreboard devices' settings and ordered delivery, and shared layouts
+	scoreboards       map[string]*scoreboardTarget
+	scoreboardLayouts map[string]ScoreboardLayout
+	startLists        map[string]*StartList    // Imported entries by event, persisted
+	sessions          map[string]*ThrowSession // Finished sessions by ID, persisted
+	serialPresets     []SerialPreset           // User presets, persisted
+	portBindings      map[string]PortBinding   // Device role -> USB adapter, persisted
+	// Implements that let training throws count towards bests, persisted
+	competitionImplements CompetitionImplements
+	// In-flight readings per device, for CancelMeasurement
+	measurements   map[string]map[uint64]context.CancelFunc
+	measurementSeq uint64
+	// Optional HTTP/JSON API
+	api       *apiServer
+	apiConfig APIServerConfig
+	// Live results feed, non-mark attempts (fouls, passes) and measured wind
+	feed        *liveFeed
+	attempts    []AttemptRecord
+	windSamples []WindSampleEvent
+	// Replication to (or, when central, from) other instances
+	sync   *syncer
+	synced *syncStore
 	// Throw coordinate tracking
-	throwCoordinates []ThrowCoordinate `json:"throwCoordinates"` // All recorded throws
-	currentSession   *ThrowSession     `json:"currentSession"`   // Current active session
+	throwCoordinates []ThrowCoordinate // All recorded throws
+	// Frontend events raised under stateMux, sent by unlockState
+	pendingEvents []pendingEvent
 }
 
 // --- App Lifecycle & Helpers ---
 func NewApp() *App {
-	return &App{
-		devices:          make(map[string]*Device),
-		windBuffer:       make([]WindReading, 0, windBufferSize),
-		CalibrationStore: make(map[string]*EDMCalibrationData),
-		demoSim:          make(map[string]*DemoSimulation),
-		throwCoordinates: make([]ThrowCoordinate, 0),
-		demoMode:         false,
-	}
+	a := &App{
+		devices:               make(map[string]*Device),
+		windBuffer:            make([]WindReading, 0, windBufferSize),
+		CalibrationStore:      make(map[string]*EDMCalibrationData),
+		demoSim:               make(map[string]*DemoSimulation),
+		stations:              make(map[string]*station),
+		scoreboards:           make(map[string]*scoreboardTarget),
+		scoreboardLayouts:     make(map[string]ScoreboardLayout),
+		startLists:            loadStartLists(),
+		sessions:              loadSessionArchive(),
+		serialPresets:         loadSerialPresets(),
+		portBindings:          loadPortBindings(),
+		competitionImplements: loadCompetitionImplements(),
+		measurements:          make(map[string]map[uint64]context.CancelFunc),
+		apiConfig:             loadAPIServerConfig(),
+		feed:                  newLiveFeed(),
+		sync:                  newSyncer(),
+		synced:                loadSyncStore(),
+		throwCoordinates:      make([]ThrowCoordinate, 0),
+		demoMode:              false,
+	}
+	// Results merged from stations before a restart
+	a.throwCoordinates = append(a.throwCoordinates, a.synced.marks...)
+	a.attempts = append(a.attempts, a.synced.attempts...)
+	a.windSamples = append(a.windSamples, a.synced.wind...)
+	go a.superviseDevices()
+	go a.runSync()
+	return a
 }
 
 func (a *App) wailsStartup(ctx context.Context) {
 	a.ctx = ctx
+	go func() {
+		for _, msg := range a.ConnectBoundDevices() {
+			log.Printf("Auto-connect: %s", msg)
+		}
+	}()
+	if a.apiConfig.Enabled {
+		if _, err := a.StartAPIServer(a.apiConfig); err != nil {
+			log.Printf("Could not start API server: %v", err)
+		}
+	}
 }
 
 func (a *App) wailsShutdown(ctx context.Context) {
+	a.StopAPIServer()
+	a.sync.flushOutbox()
 	a.stateMux.Lock()
 	defer a.stateMux.Unlock()
 	for _, dev := range a.devices {
 		if dev.cancelListener != nil {
 			dev.cancelListener()
 		}
+		if dev.io != nil {
+			dev.io.stop()
+		}
 		if dev.Conn != nil {
 			dev.Conn.Close()
 		}
 	}
+	for board := range a.scoreboards {
+		a.stopScoreboardLocked(board)
+	}
 }
 
 func parseDDDMMSSAngle(angleStr string) (float64, error) {
@@ -236,8 +338,8 @@ func (a *App) parseWindResponse(raw string) (float64, bool) {
 
 // --- Demo Simulation Functions ---
 
-// Initialize demo simulation for a device based on calibration
-func (a *App) initDemoSimulation(devType string, targetRadius float64) {
+// Initialize demo simulation for a device based on calibration. Caller holds stateMux.
+func (a *App) initDemoSimulationLocked(devType string, targetRadius float64) {
 	// Generate realistic station position
 	// Station should be 5-15 meters from centre at various angles
 	distance := 8.0 + rand.Float64()*7.0  // 8-15 meters
@@ -255,11 +357,11 @@ func (a *App) initDemoSimulation(devType string, targetRadius float64) {
 	}
 }
 
-// Generate realistic demo centre reading
-func (a *App) generateDemoCentreReading(devType string, targetRadius float64) *AveragedEDMReading {
+// Generate realistic demo centre reading. Caller holds stateMux.
+func (a *App) generateDemoCentreReadingLocked(devType string, targetRadius float64) *AveragedEDMReading {
 	sim, exists := a.demoSim[devType]
 	if !exists {
-		a.initDemoSimulation(devType, targetRadius)
+		a.initDemoSimulationLocked(devType, targetRadius)
 		sim = a.demoSim[devType]
 	}
 
@@ -299,12 +401,12 @@ func (a *App) generateDemoCentreReading(devType string, targetRadius float64) *A
 	return reading
 }
 
-// Generate realistic demo edge reading within tolerance
-func (a *App) generateDemoEdgeReading(devType string, targetRadius float64) *AveragedEDMReading {
+// Generate realistic demo edge reading within tolerance. Caller holds stateMux.
+func (a *App) generateDemoEdgeReadingLocked(devType string, targetRadius float64) *AveragedEDMReading {
 	sim, exists := a.demoSim[devType]
 	if !exists || sim.centreReading == nil {
 		log.Printf("DEMO ERROR: No centre reading found for %s, generating fallback", devType)
-		a.generateDemoCentreReading(devType, targetRadius)
+		a.generateDemoCentreReadingLocked(devType, targetRadius)
 		sim = a.demoSim[devType]
 	}
 
@@ -357,12 +459,12 @@ func (a *App) generateDemoEdgeReading(devType string, targetRadius float64) *Ave
 	return reading
 }
 
-// Generate realistic demo throw reading
-func (a *App) generateDemoThrowReading(devType string, targetRadius float64, circleType string) *AveragedEDMReading {
+// Generate realistic demo throw reading. Caller holds stateMux.
+func (a *App) generateDemoThrowReadingLocked(devType string, targetRadius float64, circleType string) *AveragedEDMReading {
 	sim, exists := a.demoSim[devType]
 	if !exists || sim.centreReading == nil {
 		log.Printf("DEMO ERROR: No centre reading found for %s, generating fallback", devType)
-		a.generateDemoCentreReading(devType, targetRadius)
+		a.generateDemoCentreReadingLocked(devType, targetRadius)
 		sim = a.demoSim[devType]
 	}
 
@@ -444,64 +546,135 @@ func (a *App) ListSerialPorts() ([]string, error) {
 }
 
 func (a *App) ConnectSerialDevice(devType, portName string) (string, error) {
+	return a.connectSerial(devType, portName, defaultSerialSettings())
+}
+
+func (a *App) connectSerial(devType, portName string, settings SerialSettings) (string, error) {
+	// Enumerating ports is slow, so check for the bound adapter before locking
+	serialNumber, bound := a.boundAdapterOn(devType, portName)
+
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 	if d, ok := a.devices[devType]; ok && d.Conn != nil {
 		if d.cancelListener != nil {
 			d.cancelListener()
 		}
+		d.io.stop()
 		d.Conn.Close()
 	}
-	mode := &serial.Mode{BaudRate: 9600}
-	port, err := serial.Open(portName, mode)
+	port, err := openDeviceConn("serial", portName, settings)
 	if err != nil {
 		return "", err
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	a.devices[devType] = &Device{Conn: port, ConnectionType: "serial", Address: portName, cancelListener: cancel}
+	dev := &Device{Conn: port, io: newDeviceIO(port), ConnectionType: "serial", Address: portName, cancelListener: cancel, lastActivity: time.Now(), serialSettings: settings}
+	if bound {
+		// Lets the supervisor follow the adapter to a new port after a replug
+		dev.usbSerialNumber = serialNumber
+		a.recordBoundPortLocked(devType, portName)
+	}
+	if isScoreboardDevice(devType) {
+		driver, err := newScoreboardDriver(a.scoreboardTargetLocked(devType).config)
+		if err != nil {
+			cancel()
+			dev.Conn.Close()
+			return "", err
+		}
+		dev.driver = driver
+	}
+	a.devices[devType] = dev
+	a.setDeviceStateLocked(devType, dev, DeviceStateConnected)
 	if devType == "wind" {
 		go a.StartWindListener(devType, ctx)
 	}
-	if devType == "scoreboard" {
-		go a.SendToScoreboard("88:88")
+	if isScoreboardDevice(devType) {
+		a.initialiseScoreboardLocked(devType)
 	}
-	return fmt.Sprintf("Connected to %s on %s", devType, portName), nil
+	return fmt.Sprintf("Connected to %s on %s (%s)", devType, portName, settings), nil
 }
 
 func (a *App) ConnectNetworkDevice(devType, ipAddress string, port int) (string, error) {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 	if d, ok := a.devices[devType]; ok && d.Conn != nil {
 		if d.cancelListener != nil {
 			d.cancelListener()
 		}
+		d.io.stop()
 		d.Conn.Close()
 	}
 	address := net.JoinHostPort(ipAddress, strconv.Itoa(port))
-	conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+	conn, err := openDeviceConn("network", address, SerialSettings{})
 	if err != nil {
 		return "", err
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	a.devices[devType] = &Device{Conn: conn, ConnectionType: "network", Address: address, cancelListener: cancel}
+	dev := &Device{Conn: conn, io: newDeviceIO(conn), ConnectionType: "network", Address: address, cancelListener: cancel, lastActivity: time.Now()}
+	if isScoreboardDevice(devType) {
+		driver, err := newScoreboardDriver(a.scoreboardTargetLocked(devType).config)
+		if err != nil {
+			cancel()
+			dev.Conn.Close()
+			return "", err
+		}
+		dev.driver = driver
+	}
+	a.devices[devType] = dev
+	a.setDeviceStateLocked(devType, dev, DeviceStateConnected)
 	if devType == "wind" {
 		go a.StartWindListener(devType, ctx)
 	}
-	if devType == "scoreboard" {
-		go a.SendToScoreboard("88:88")
+	if isScoreboardDevice(devType) {
+		a.initialiseScoreboardLocked(devType)
 	}
 	return fmt.Sprintf("Connected to %s at %s", devType, address), nil
 }
 
+// openDeviceConn opens a serial port or dials a TCP address. Used for both the
+// initial connection and reconnecting to the same Address.
+func openDeviceConn(connectionType, address string, settings SerialSettings) (io.ReadWriteCloser, error) {
+	switch connectionType {
+	case "serial":
+		return openSerialPort(address, settings)
+	case "network":
+		return net.DialTimeout("tcp", address, 5*time.Second)
+	}
+	return nil, fmt.Errorf("unknown connection type '%s'", connectionType)
+}
+
+// setConnReadTimeout bounds the next reads on a serial port or TCP socket.
+// A zero duration removes the timeout.
+func setConnReadTimeout(conn io.ReadWriteCloser, d time.Duration) {
+	switch c := conn.(type) {
+	case net.Conn:
+		if d > 0 {
+			c.SetReadDeadline(time.Now().Add(d))
+		} else {
+			c.SetReadDeadline(time.Time{})
+		}
+	case serial.Port:
+		if d > 0 {
+			c.SetReadTimeout(d)
+		} else {
+			c.SetReadTimeout(serial.NoTimeout)
+		}
+	}
+}
+
 func (a *App) DisconnectDevice(devType string) (string, error) {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 	if dev, ok := a.devices[devType]; ok && dev.Conn != nil {
 		if dev.cancelListener != nil {
 			dev.cancelListener()
 		}
+		dev.io.stop()
 		dev.Conn.Close()
 		delete(a.devices, devType)
+		if isScoreboardDevice(devType) {
+			a.stopScoreboardLocked(devType)
+		}
+		a.setDeviceStateLocked(devType, dev, DeviceStateDisconnected)
 		return fmt.Sprintf("Disconnected %s", devType), nil
 	}
 	return "", fmt.Errorf("%s not connected", devType)
@@ -518,7 +691,7 @@ func (a *App) GetCalibration(devType string) (*EDMCalibrationData, error) {
 
 func (a *App) SaveCalibration(devType string, data EDMCalibrationData) error {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 	if existingCal, ok := a.CalibrationStore[devType]; ok {
 		data.Timestamp = existingCal.Timestamp
 	}
@@ -529,12 +702,13 @@ func (a *App) SaveCalibration(devType string, data EDMCalibrationData) error {
 		delete(a.demoSim, devType)
 	}
 
+	a.emitCalibrationChangedLocked(devType, &data)
 	return nil
 }
 
 func (a *App) ResetCalibration(devType string) error {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 	delete(a.CalibrationStore, devType)
 
 	// Reset demo simulation
@@ -542,28 +716,55 @@ func (a *App) ResetCalibration(devType string) error {
 		delete(a.demoSim, devType)
 	}
 
+	a.emitCalibrationChangedLocked(devType, nil)
 	return nil
 }
 
-func (a *App) _triggerSingleEDMRead(dev *Device) (*ParsedEDMReading, error) {
-	if _, err := dev.Conn.Write(edmReadCommand); err != nil {
+// _triggerSingleEDMRead runs inside a device I/O job. Stale input is
+// flushed first so a late reply to an earlier trigger cannot be taken as
+// the answer to this one.
+func (a *App) _triggerSingleEDMRead(devType string, dio *deviceIO) (*ParsedEDMReading, error) {
+	dio.flushInput()
+	if err := dio.write(edmReadCommand); err != nil {
 		return nil, err
 	}
-	if dev.ConnectionType == "network" {
-		if conn, ok := dev.Conn.(net.Conn); ok {
-			conn.SetReadDeadline(time.Now().Add(edmReadTimeout))
-			defer conn.SetReadDeadline(time.Time{})
-		}
-	}
-	r := bufio.NewReader(dev.Conn)
-	resp, err := r.ReadString('\n')
+	resp, err := dio.readLine(edmReadTimeout)
 	if err != nil {
 		return nil, err
 	}
-	return parseEDMResponseString(resp)
+	parsed, err := parseEDMResponseString(resp)
+	if err != nil {
+		a.emitEDMRejected(devType, fmt.Sprintf("malformed response: %v", err), strings.TrimSpace(resp))
+		return nil, fmt.Errorf("%w: %v", errMalformedEDMResponse, err)
+	}
+	a.emitEvent(EventEDMReading, EDMReadingEvent{
+		DeviceType:      devType,
+		Raw:             strings.TrimSpace(resp),
+		SlopeDistanceMm: parsed.SlopeDistanceMm,
+		VAzDecimal:      parsed.VAzDecimal,
+		HARDecimal:      parsed.HARDecimal,
+		Timestamp:       time.Now().UTC(),
+	})
+	return parsed, nil
 }
 
+var errMalformedEDMResponse = errors.New("malformed EDM response")
+
+type edmReadError struct {
+	which string
+	err   error
+}
+
+func (e *edmReadError) Error() string { return fmt.Sprintf("%s read failed: %v", e.which, e.err) }
+func (e *edmReadError) Unwrap() error { return e.err }
+
 func (a *App) GetReliableEDMReading(devType string) (*AveragedEDMReading, error) {
+	ctx, done := a.beginMeasurement(devType)
+	defer done()
+	return a.getReliableEDMReading(ctx, devType)
+}
+
+func (a *App) getReliableEDMReading(ctx context.Context, devType string) (*AveragedEDMReading, error) {
 	a.stateMux.Lock()
 	if a.demoMode {
 		a.stateMux.Unlock()
@@ -573,23 +774,59 @@ func (a *App) GetReliableEDMReading(devType string) (*AveragedEDMReading, error)
 			HARDecimal:      rand.Float64() * 360.0,
 		}, nil
 	}
+	// The supervisor replaces Conn and io and changes state under stateMux,
+	// so take what is needed before unlocking
 	device, ok := a.devices[devType]
+	connected := ok && device.Conn != nil
+	var state string
+	var edmIO *deviceIO
+	if connected {
+		state, edmIO = device.state, device.io
+	}
 	a.stateMux.Unlock()
-	if !ok || device.Conn == nil {
+	if !connected {
 		return nil, fmt.Errorf("EDM device type '%s' not connected", devType)
 	}
-
-	r1, e1 := a._triggerSingleEDMRead(device)
-	if e1 != nil {
-		return nil, fmt.Errorf("first read failed: %w", e1)
+	if state == DeviceStateReconnecting {
+		return nil, fmt.Errorf("EDM device type '%s' is reconnecting", devType)
 	}
 
-	time.Sleep(delayBetweenReadsInPair)
+	// Both reads run as one job so no other caller can use the EDM in between
+	var r1, r2 *ParsedEDMReading
+	err := edmIO.do(ctx, func(dio *deviceIO) error {
+		var e1, e2 error
+		r1, e1 = a._triggerSingleEDMRead(devType, dio)
+		if e1 != nil {
+			return &edmReadError{which: "first", err: e1}
+		}
+
+		if err := dio.sleep(delayBetweenReadsInPair); err != nil {
+			return err
+		}
 
-	r2, e2 := a._triggerSingleEDMRead(device)
-	if e2 != nil {
-		return nil, fmt.Errorf("second read failed: %w", e2)
+		r2, e2 = a._triggerSingleEDMRead(devType, dio)
+		if e2 != nil {
+			return &edmReadError{which: "second", err: e2}
+		}
+		return nil
+	})
+	if errors.Is(err, context.Canceled) {
+		a.emitEDMRejected(devType, errMeasurementCancelled.Error(), "")
+		return nil, errMeasurementCancelled
 	}
+	if err != nil {
+		if !errors.Is(err, errMalformedEDMResponse) {
+			a.emitEDMRejected(devType, err.Error(), "")
+		}
+		var readErr *edmReadError
+		if errors.As(err, &readErr) && isConnectionFailure(readErr.err) && !errors.Is(readErr.err, errMalformedEDMResponse) {
+			a.reportDeviceFailure(devType, readErr.err)
+		}
+		return nil, err
+	}
+	a.stateMux.Lock()
+	device.lastActivity = time.Now()
+	a.stateMux.Unlock()
 
 	if math.Abs(r1.SlopeDistanceMm-r2.SlopeDistanceMm) <= sdToleranceMm {
 		return &AveragedEDMReading{
@@ -598,11 +835,19 @@ func (a *App) GetReliableEDMReading(devType string) (*AveragedEDMReading, error)
 			HARDecimal:      (r1.HARDecimal + r2.HARDecimal) / 2.0,
 		}, nil
 	}
-	return nil, fmt.Errorf("readings inconsistent. R1(SD): %.0fmm, R2(SD): %.0fmm", r1.SlopeDistanceMm, r2.SlopeDistanceMm)
+	err = fmt.Errorf("readings inconsistent. R1(SD): %.0fmm, R2(SD): %.0fmm", r1.SlopeDistanceMm, r2.SlopeDistanceMm)
+	a.emitEDMRejected(devType, err.Error(), "")
+	return nil, err
 }
 
 // Updated EDM functions using verified methodology with dynamic demo readings
 func (a *App) SetCircleCentre(devType string) (*EDMCalibrationData, error) {
+	ctx, done := a.beginMeasurement(devType)
+	defer done()
+	return a.setCircleCentre(ctx, devType)
+}
+
+func (a *App) setCircleCentre(ctx context.Context, devType string) (*EDMCalibrationData, error) {
 	var reading *AveragedEDMReading
 	var err error
 
@@ -622,12 +867,16 @@ func (a *App) SetCircleCentre(devType string) (*EDMCalibrationData, error) {
 
 	if isDemoMode {
 		// Use dynamic demo data with delay
-		time.Sleep(CENTRE_DELAY)
-		reading = a.generateDemoCentreReading(devType, targetRadius)
+		if err := sleepCtx(ctx, CENTRE_DELAY); err != nil {
+			return nil, errMeasurementCancelled
+		}
+		a.stateMux.Lock()
+		reading = a.generateDemoCentreReadingLocked(devType, targetRadius)
+		a.stateMux.Unlock()
 		log.Printf("DEMO: Centre reading for %s circle (%.4fm) - SD: %.0fmm, VAz: %.4f°, HAR: %.4f°",
 			circleType, targetRadius, reading.SlopeDistanceMm, reading.VAzDecimal, reading.HARDecimal)
 	} else {
-		reading, err = a.GetReliableEDMReading(devType)
+		reading, err = a.getReliableEDMReading(ctx, devType)
 		if err != nil {
 			return nil, fmt.Errorf("could not get centre reading: %w", err)
 		}
@@ -654,19 +903,27 @@ func (a *App) SetCircleCentre(devType string) (*EDMCalibrationData, error) {
 	log.Printf("Horizontal distance to centre: %.4fm", horizontalDistance)
 
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 
 	// Update calibration data while preserving circle type and radius
 	cal.StationCoordinates = EDMPoint{X: stationX, Y: stationY}
 	cal.IsCentreSet = true
 	cal.EdgeVerificationResult = nil // Reset edge verification
+	cal.IsSectorSet = false          // The bearing is relative to the EDM's zero, which may have moved
 	cal.Timestamp = time.Now().UTC()
 
 	a.CalibrationStore[devType] = cal
+	a.emitCalibrationChangedLocked(devType, cal)
 	return cal, nil
 }
 
 func (a *App) VerifyCircleEdge(devType string) (*EDMCalibrationData, error) {
+	ctx, done := a.beginMeasurement(devType)
+	defer done()
+	return a.verifyCircleEdge(ctx, devType)
+}
+
+func (a *App) verifyCircleEdge(ctx context.Context, devType string) (*EDMCalibrationData, error) {
 	a.stateMux.Lock()
 	cal, exists := a.CalibrationStore[devType]
 	isDemoMode := a.demoMode
@@ -685,12 +942,16 @@ func (a *App) VerifyCircleEdge(devType string) (*EDMCalibrationData, error) {
 
 	if isDemoMode {
 		// Use dynamic demo data with delay
-		time.Sleep(EDGE_DELAY)
-		reading = a.generateDemoEdgeReading(devType, targetRadius)
+		if err := sleepCtx(ctx, EDGE_DELAY); err != nil {
+			return nil, errMeasurementCancelled
+		}
+		a.stateMux.Lock()
+		reading = a.generateDemoEdgeReadingLocked(devType, targetRadius)
+		a.stateMux.Unlock()
 		log.Printf("DEMO: Edge reading for %s circle (%.4fm) - SD: %.0fmm, VAz: %.4f°, HAR: %.4f°",
 			circleType, targetRadius, reading.SlopeDistanceMm, reading.VAzDecimal, reading.HARDecimal)
 	} else {
-		reading, err = a.GetReliableEDMReading(devType)
+		reading, err = a.getReliableEDMReading(ctx, devType)
 		if err != nil {
 			return nil, fmt.Errorf("could not get edge reading: %w", err)
 		}
@@ -745,12 +1006,64 @@ func (a *App) VerifyCircleEdge(devType string) (*EDMCalibrationData, error) {
 
 	a.stateMux.Lock()
 	a.CalibrationStore[devType] = cal
+	a.emitCalibrationChangedLocked(devType, cal)
+	a.unlockState()
+
+	return cal, nil
+}
+
+// MeasureSectorCentreline takes a reading to a marker on the sector
+// centreline, beyond the circle. Heatmaps, analytics and field exports use
+// it as the sector direction; until it is measured they use the EDM's zero
+// bearing.
+func (a *App) MeasureSectorCentreline(devType string) (*EDMCalibrationData, error) {
+	ctx, done := a.beginMeasurement(devType)
+	defer done()
+
+	a.stateMux.Lock()
+	cal, exists := a.CalibrationStore[devType]
+	isDemoMode := a.demoMode
+	if !exists || !cal.IsCentreSet {
+		a.stateMux.Unlock()
+		return nil, fmt.Errorf("must set circle centre first")
+	}
+	station := cal.StationCoordinates
+	targetRadius := cal.TargetRadius
 	a.stateMux.Unlock()
 
+	bearing := 0.0 // Demo throws land either side of the zero bearing
+	if !isDemoMode {
+		reading, err := a.getReliableEDMReading(ctx, devType)
+		if err != nil {
+			return nil, fmt.Errorf("could not get centreline reading: %w", err)
+		}
+		horizontalDistance := reading.SlopeDistanceMm / 1000.0 * math.Sin(reading.VAzDecimal*math.Pi/180.0)
+		harRad := reading.HARDecimal * math.Pi / 180.0
+		markerX := station.X + horizontalDistance*math.Cos(harRad)
+		markerY := station.Y + horizontalDistance*math.Sin(harRad)
+		if math.Hypot(markerX, markerY) <= targetRadius {
+			return nil, fmt.Errorf("centreline marker is inside the circle - place it out in the sector")
+		}
+		bearing = math.Atan2(markerY, markerX) * 180 / math.Pi
+	}
+	log.Printf("Sector centreline for %s: %.4f° from the EDM's zero", devType, bearing)
+
+	a.stateMux.Lock()
+	defer a.unlockState()
+	cal.SectorBearing = bearing
+	cal.IsSectorSet = true
+	cal.Timestamp = time.Now().UTC()
+	a.emitCalibrationChangedLocked(devType, cal)
 	return cal, nil
 }
 
 func (a *App) MeasureThrow(devType string) (string, error) {
+	ctx, done := a.beginMeasurement(devType)
+	defer done()
+	return a.measureThrow(ctx, devType)
+}
+
+func (a *App) measureThrow(ctx context.Context, devType string) (string, error) {
 	a.stateMux.Lock()
 	cal, exists := a.CalibrationStore[devType]
 	isDemoMode := a.demoMode
@@ -759,24 +1072,45 @@ func (a *App) MeasureThrow(devType string) (string, error) {
 		return "", fmt.Errorf("EDM is not calibrated - centre not set")
 	}
 
-	// Check edge verification if not in demo mode
-	if !isDemoMode && (cal.EdgeVerificationResult == nil || !cal.EdgeVerificationResult.IsInTolerance) {
+	// Check edge verification if not in demo mode. Training skips the
+	// competition requirement.
+	st := a.stationLocked(devType)
+	training := st.training()
+	if !isDemoMode && !training && (cal.EdgeVerificationResult == nil || !cal.EdgeVerificationResult.IsInTolerance) {
 		a.stateMux.Unlock()
 		return "", fmt.Errorf("EDM must be calibrated with valid edge verification before measurement")
 	}
 
 	targetRadius := cal.TargetRadius
 	circleType := cal.SelectedCircleType
+	event := st.event
+	sessionID := ""
+	if st.session != nil {
+		if err := st.session.checkScope(circleType, event); err != nil {
+			a.stateMux.Unlock()
+			return "", err
+		}
+		sessionID = st.session.SessionID
+	}
+	athlete := st.board
+	implement, tags := st.implement, st.tags
 	a.stateMux.Unlock()
 
 	var reading *AveragedEDMReading
 	var err error
 
 	if isDemoMode {
-		time.Sleep(THROW_DELAY)
-		reading = a.generateDemoThrowReading(devType, targetRadius, circleType)
+		if err := sleepCtx(ctx, THROW_DELAY); err != nil {
+			return "", errMeasurementCancelled
+		}
+		if a.demoThrowHook != nil {
+			a.demoThrowHook(devType)
+		}
+		a.stateMux.Lock()
+		reading = a.generateDemoThrowReadingLocked(devType, targetRadius, circleType)
+		a.stateMux.Unlock()
 	} else {
-		reading, err = a.GetReliableEDMReading(devType)
+		reading, err = a.getReliableEDMReading(ctx, devType)
 		if err != nil {
 			return "", fmt.Errorf("could not get throw reading: %w", err)
 		}
@@ -816,16 +1150,26 @@ func (a *App) MeasureThrow(devType string) (string, error) {
 
 	// STORE THE COORDINATES
 	a.storeThrowCoordinate(ThrowCoordinate{
+		ID:         newRecordID(),
+		Origin:     a.sync.origin(),
 		X:          absoluteThrowX,
 		Y:          absoluteThrowY,
 		Distance:   finalThrowDistance,
 		CircleType: circleType,
 		Timestamp:  time.Now().UTC(),
+		AthleteID:  athlete.Bib,
+		Station:    devType,
+		Event:      event,
+		Attempt:    athlete.Attempt,
+		SessionID:  sessionID,
+		Training:   training,
+		Implement:  implement,
+		Tags:       tags,
 		EDMReading: fmt.Sprintf("%.0f %.6f %.6f", reading.SlopeDistanceMm, reading.VAzDecimal, reading.HARDecimal),
 	})
 
 	result := fmt.Sprintf("%.2f m", finalThrowDistance)
-	go a.SendToScoreboard(strings.TrimSuffix(result, " m"))
+	go a.recordScoreboardMark(devType, strings.TrimSuffix(result, " m"))
 	return result, nil
 }
 
@@ -834,71 +1178,134 @@ func (a *App) MeasureThrow(devType string) (string, error) {
 // Store throw coordinate
 func (a *App) storeThrowCoordinate(coord ThrowCoordinate) {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+
+	// Add to the session it was measured in, which may have ended since. If
+	// it has been deleted or merged away meanwhile the throw keeps no session.
+	if st, ok := a.findStationLocked(coord.Station); ok && st.session != nil && st.session.SessionID == coord.SessionID {
+		st.session.Coordinates = append(st.session.Coordinates, coord)
+		updateSessionStatistics(st.session)
+	} else if session, ok := a.sessions[coord.SessionID]; ok && coord.SessionID != "" {
+		session.Coordinates = append(session.Coordinates, coord)
+		updateSessionStatistics(session)
+		a.queueSessionSync(session)
+		a.archiveSessionLocked(session)
+	} else {
+		coord.SessionID = ""
+	}
 
 	// Add to overall coordinates list
 	a.throwCoordinates = append(a.throwCoordinates, coord)
 
-	// Add to current session if active
-	if a.currentSession != nil && a.currentSession.CircleType == coord.CircleType {
-		a.currentSession.Coordinates = append(a.currentSession.Coordinates, coord)
-		a.updateSessionStatistics()
-	}
+	a.emitEventLocked(EventMarkRecorded, coord)
+	a.sync.enqueue(func(b *SyncBatch) { b.Marks = append(b.Marks, coord) })
+	a.unlockState()
+
+	a.publishMark(coord)
 
 	log.Printf("Stored throw coordinate: (%.4f, %.4f) for %s, distance: %.2fm",
 		coord.X, coord.Y, coord.CircleType, coord.Distance)
 }
 
-// Session management functions
-func (a *App) StartThrowSession(circleType string, sessionID string) error {
+// Session management functions. Each station has its own session.
+func (a *App) StartThrowSession(devType string, circleType string, sessionID string) error {
 	a.stateMux.Lock()
 	defer a.stateMux.Unlock()
+	st := a.stationLocked(devType)
+
+	if sessionID == "" {
+		sessionID = newRecordID()
+	} else if a.sessionInUseLocked(sessionID) {
+		return fmt.Errorf("session '%s' already exists", sessionID)
+	}
 
 	// End current session if exists
-	if a.currentSession != nil {
+	if st.session != nil {
 		now := time.Now().UTC()
-		a.currentSession.EndTime = &now
-		a.updateSessionStatistics()
+		st.session.EndTime = &now
+		updateSessionStatistics(st.session)
+		a.queueSessionSync(st.session)
+		a.archiveSessionLocked(st.session)
 	}
 
-	// Start new session
-	a.currentSession = &ThrowSession{
+	// Start new session. Training settings do not carry over.
+	st.implement, st.tags, st.rosterIndex = "", nil, 0
+	st.session = &ThrowSession{
 		SessionID:   sessionID,
+		Station:     devType,
+		Origin:      a.sync.origin(),
 		CircleType:  circleType,
+		Event:       st.event,
 		StartTime:   time.Now().UTC(),
 		Coordinates: make([]ThrowCoordinate, 0),
 	}
+	a.queueSessionSync(st.session)
 
-	log.Printf("Started new throw session: %s for %s", sessionID, circleType)
+	log.Printf("Started new throw session on %s: %s for %s", devType, sessionID, circleType)
 	return nil
 }
 
-func (a *App) EndThrowSession() (*ThrowSession, error) {
+func (a *App) EndThrowSession(devType string) (*ThrowSession, error) {
 	a.stateMux.Lock()
 	defer a.stateMux.Unlock()
+	st, ok := a.findStationLocked(devType)
 
-	if a.currentSession == nil {
-		return nil, fmt.Errorf("no active session")
+	if !ok || st.session == nil {
+		return nil, fmt.Errorf("no active session on %s", devType)
 	}
 
 	now := time.Now().UTC()
-	a.currentSession.EndTime = &now
-	a.updateSessionStatistics()
+	st.session.EndTime = &now
+	updateSessionStatistics(st.session)
 
-	session := a.currentSession
-	a.currentSession = nil
+	session := st.session
+	st.session = nil
+	a.queueSessionSync(session)
+	a.archiveSessionLocked(session)
 
 	log.Printf("Ended throw session: %s with %d throws", session.SessionID, len(session.Coordinates))
-	return session, nil
+	return cloneSession(session), nil
+}
+
+// checkScope rejects a throw the session was not started for, rather than
+// recording it outside the session
+func (s *ThrowSession) checkScope(circleType, event string) error {
+	if circleType != s.CircleType {
+		return fmt.Errorf("session '%s' is for %s but the EDM is set to %s - end the session or select %s again",
+			s.SessionID, s.CircleType, circleType, s.CircleType)
+	}
+	if event != s.Event {
+		return fmt.Errorf("session '%s' is for event '%s' but the station is measuring '%s'", s.SessionID, s.Event, event)
+	}
+	return nil
 }
 
 // Update session statistics
-func (a *App) updateSessionStatistics() {
-	if a.currentSession == nil || len(a.currentSession.Coordinates) == 0 {
+func updateSessionStatistics(session *ThrowSession) {
+	if session == nil || len(session.Coordinates) == 0 {
 		return
 	}
+	session.Statistics = throwStatistics(session.Coordinates)
+	if session.Mode != SessionTraining {
+		return
+	}
+	byAthlete := make(map[string][]ThrowCoordinate)
+	byImplement := make(map[string][]ThrowCoordinate)
+	for _, coord := range session.Coordinates {
+		byAthlete[coord.AthleteID] = append(byAthlete[coord.AthleteID], coord)
+		byImplement[coord.Implement] = append(byImplement[coord.Implement], coord)
+	}
+	session.Statistics.ByAthlete = make(map[string]*SessionStatistics, len(byAthlete))
+	for athlete, coords := range byAthlete {
+		session.Statistics.ByAthlete[athlete] = throwStatistics(coords)
+	}
+	session.Statistics.ByImplement = make(map[string]*SessionStatistics, len(byImplement))
+	for implement, coords := range byImplement {
+		session.Statistics.ByImplement[implement] = throwStatistics(coords)
+	}
+}
 
-	coords := a.currentSession.Coordinates
+// throwStatistics summarises a non-empty set of throws
+func throwStatistics(coords []ThrowCoordinate) *SessionStatistics {
 	stats := &SessionStatistics{
 		TotalThrows: len(coords),
 	}
@@ -933,8 +1340,7 @@ func (a *App) updateSessionStatistics() {
 		sumSquaredDist += dx*dx + dy*dy
 	}
 	stats.SpreadRadius = math.Sqrt(sumSquaredDist / float64(len(coords)))
-
-	a.currentSession.Statistics = stats
+	return stats
 }
 
 // Export functions
@@ -965,101 +1371,6 @@ func (a *App) ExportThrowCoordinatesForCircle(circleType string) ([]ThrowCoordin
 	return filtered, nil
 }
 
-func (a *App) ExportThrowCoordinatesAsCSV() (string, error) {
-	a.stateMux.Lock()
-	coordinates := make([]ThrowCoordinate, len(a.throwCoordinates))
-	copy(coordinates, a.throwCoordinates)
-	a.stateMux.Unlock()
-
-	var csvData strings.Builder
-	csvData.WriteString("X,Y,Distance,CircleType,Timestamp,AthleteID,CompetitionRound,EDMReading\n")
-
-	for _, coord := range coordinates {
-		csvData.WriteString(fmt.Sprintf("%.6f,%.6f,%.3f,%s,%s,%s,%s,\"%s\"\n",
-			coord.X, coord.Y, coord.Distance, coord.CircleType,
-			coord.Timestamp.Format("2006-01-02T15:04:05.000Z"),
-			coord.AthleteID, coord.CompetitionRound, coord.EDMReading))
-	}
-
-	log.Printf("Exported %d coordinates as CSV", len(coordinates))
-	return csvData.String(), nil
-}
-
-func (a *App) ExportHeatmapData(circleType string, gridSize float64) (map[string]interface{}, error) {
-	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
-
-	var coordinates []ThrowCoordinate
-	for _, coord := range a.throwCoordinates {
-		if coord.CircleType == circleType {
-			coordinates = append(coordinates, coord)
-		}
-	}
-
-	if len(coordinates) == 0 {
-		return nil, fmt.Errorf("no coordinates found for %s", circleType)
-	}
-
-	// Find bounds
-	minX, maxX := coordinates[0].X, coordinates[0].X
-	minY, maxY := coordinates[0].Y, coordinates[0].Y
-
-	for _, coord := range coordinates {
-		if coord.X < minX {
-			minX = coord.X
-		}
-		if coord.X > maxX {
-			maxX = coord.X
-		}
-		if coord.Y < minY {
-			minY = coord.Y
-		}
-		if coord.Y > maxY {
-			maxY = coord.Y
-		}
-	}
-
-	// Create grid
-	gridWidth := int(math.Ceil((maxX-minX)/gridSize)) + 1
-	gridHeight := int(math.Ceil((maxY-minY)/gridSize)) + 1
-
-	heatmapGrid := make([][]int, gridHeight)
-	for i := range heatmapGrid {
-		heatmapGrid[i] = make([]int, gridWidth)
-	}
-
-	// Populate grid
-	for _, coord := range coordinates {
-		gridX := int((coord.X - minX) / gridSize)
-		gridY := int((coord.Y - minY) / gridSize)
-
-		if gridX >= 0 && gridX < gridWidth && gridY >= 0 && gridY < gridHeight {
-			heatmapGrid[gridY][gridX]++
-		}
-	}
-
-	result := map[string]interface{}{
-		"circleType": circleType,
-		"gridSize":   gridSize,
-		"bounds": map[string]float64{
-			"minX": minX,
-			"maxX": maxX,
-			"minY": minY,
-			"maxY": maxY,
-		},
-		"gridWidth":   gridWidth,
-		"gridHeight":  gridHeight,
-		"heatmap":     heatmapGrid,
-		"totalThrows": len(coordinates),
-		"coordinates": coordinates, // Include raw coordinates for overlay
-	}
-
-	log.Printf("Generated heatmap for %s: %dx%d grid with %d throws",
-		circleType, gridWidth, gridHeight, len(coordinates))
-
-	return result, nil
-}
-
 // Clear stored coordinates (useful for testing or new competitions)
 func (a *App) ClearThrowCoordinates() error {
 	a.stateMux.Lock()
@@ -1067,30 +1378,37 @@ func (a *App) ClearThrowCoordinates() error {
 
 	count := len(a.throwCoordinates)
 	a.throwCoordinates = make([]ThrowCoordinate, 0)
+	a.attempts = nil
+	a.windSamples = nil
+	a.synced.clear()
 
-	// End current session
-	if a.currentSession != nil {
-		now := time.Now().UTC()
-		a.currentSession.EndTime = &now
-		a.currentSession = nil
+	// End every station's session, keeping it in the archive
+	now := time.Now().UTC()
+	for _, st := range a.stations {
+		if st.session != nil {
+			st.session.EndTime = &now
+			updateSessionStatistics(st.session)
+			a.archiveSessionLocked(st.session)
+			a.queueSessionSync(st.session)
+			st.session = nil
+		}
 	}
 
 	log.Printf("Cleared %d stored throw coordinates", count)
 	return nil
 }
 
-// Get current session info
-func (a *App) GetCurrentSession() (*ThrowSession, error) {
+// Get a station's current session info
+func (a *App) GetCurrentSession(devType string) (*ThrowSession, error) {
 	a.stateMux.Lock()
 	defer a.stateMux.Unlock()
 
-	if a.currentSession == nil {
-		return nil, fmt.Errorf("no active session")
+	st, ok := a.findStationLocked(devType)
+	if !ok || st.session == nil {
+		return nil, fmt.Errorf("no active session on %s", devType)
 	}
 
-	// Return copy
-	session := *a.currentSession
-	return &session, nil
+	return cloneSession(st.session), nil
 }
 
 // Get statistics for all throws of a circle type
@@ -1108,55 +1426,23 @@ func (a *App) GetThrowStatistics(circleType string) (*SessionStatistics, error)
 	if len(coordinates) == 0 {
 		return nil, fmt.Errorf("no throws found for %s", circleType)
 	}
-
-	stats := &SessionStatistics{
-		TotalThrows: len(coordinates),
-	}
-
-	var sumX, sumY, sumDistance float64
-	var maxDist, minDist float64 = coordinates[0].Distance, coordinates[0].Distance
-
-	for _, coord := range coordinates {
-		sumX += coord.X
-		sumY += coord.Y
-		sumDistance += coord.Distance
-
-		if coord.Distance > maxDist {
-			maxDist = coord.Distance
-		}
-		if coord.Distance < minDist {
-			minDist = coord.Distance
-		}
-	}
-
-	stats.AverageX = sumX / float64(len(coordinates))
-	stats.AverageY = sumY / float64(len(coordinates))
-	stats.AverageDistance = sumDistance / float64(len(coordinates))
-	stats.MaxDistance = maxDist
-	stats.MinDistance = minDist
-
-	// Calculate spread radius
-	var sumSquaredDist float64
-	for _, coord := range coordinates {
-		dx := coord.X - stats.AverageX
-		dy := coord.Y - stats.AverageY
-		sumSquaredDist += dx*dx + dy*dy
-	}
-	stats.SpreadRadius = math.Sqrt(sumSquaredDist / float64(len(coordinates)))
-
-	return stats, nil
+	return throwStatistics(coordinates), nil
 }
 
 // --- Wind & Scoreboard Specific Functions ---
 func (a *App) StartWindListener(devType string, ctx context.Context) {
 	a.stateMux.Lock()
 	device, ok := a.devices[devType]
+	var windIO *deviceIO
+	if ok {
+		windIO = device.io
+	}
 	a.stateMux.Unlock()
 	if !ok {
 		return
 	}
 
-	scanner := bufio.NewScanner(device.Conn)
+	scanner := bufio.NewScanner(windIO.streamReader())
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
@@ -1164,26 +1450,42 @@ func (a *App) StartWindListener(devType string, ctx context.Context) {
 			return
 		default:
 			text := scanner.Text()
+			a.stateMux.Lock()
+			device.lastActivity = time.Now()
 			if val, ok := a.parseWindResponse(text); ok {
-				a.stateMux.Lock()
-				a.windBuffer = append(a.windBuffer, WindReading{Value: val, Timestamp: time.Now()})
+				now := time.Now()
+				a.windBuffer = append(a.windBuffer, WindReading{Value: val, Timestamp: now})
 				if len(a.windBuffer) > windBufferSize {
 					a.windBuffer = a.windBuffer[1:]
 				}
-				a.stateMux.Unlock()
+				a.emitEventLocked(EventWindSample, WindSampleEvent{DeviceType: devType, Value: val, Timestamp: now.UTC()})
 			}
+			a.unlockState()
+		}
+	}
+	// Scanner stopped without being cancelled: the link has dropped
+	if ctx.Err() == nil {
+		err := scanner.Err()
+		if err == nil {
+			err = io.EOF
 		}
+		a.reportDeviceFailure(devType, err)
 	}
 }
 
 func (a *App) MeasureWind(devType string) (string, error) {
 	a.stateMux.Lock()
-	defer a.stateMux.Unlock()
+	defer a.unlockState()
 
 	if a.demoMode {
 		windSpeed := (rand.Float64() * 4.0) - 2.0
 		result := fmt.Sprintf("%+.1f m/s", windSpeed)
-		go a.SendToScoreboard(result)
+		sample := WindSampleEvent{DeviceType: devType, Value: windSpeed, Timestamp: time.Now().UTC()}
+		a.windSamples = append(a.windSamples, sample)
+		a.emitEventLocked(EventWindMeasured, sample)
+		a.publishFeed(FeedWind, sample)
+		a.sync.enqueue(func(b *SyncBatch) { b.Wind = append(b.Wind, sample) })
+		go a.recordScoreboardWind(result)
 		return result, nil
 	}
 
@@ -1211,26 +1513,20 @@ func (a *App) MeasureWind(devType string) (string, error) {
 	}
 	avg := sum / float64(len(readingsInWindow))
 	result := fmt.Sprintf("%+.1f m/s", avg)
-	go a.SendToScoreboard(result)
+	sample := WindSampleEvent{DeviceType: devType, Value: avg, Timestamp: now.UTC()}
+	a.windSamples = append(a.windSamples, sample)
+	a.emitEventLocked(EventWindMeasured, sample)
+	a.publishFeed(FeedWind, sample)
+	a.sync.enqueue(func(b *SyncBatch) { b.Wind = append(b.Wind, sample) })
+	go a.recordScoreboardWind(result)
 	return result, nil
 }
 
 func (a *App) SendToScoreboard(value string) error {
 	a.stateMux.Lock()
 	defer a.stateMux.Unlock()
-	if a.demoMode {
-		log.Printf("DEMO: Would send '%s' to scoreboard", value)
-		return nil
-	}
-	scoreboard, ok := a.devices["scoreboard"]
-	if !ok || scoreboard.Conn == nil {
-		return fmt.Errorf("scoreboard not connected")
-	}
-	_, err := scoreboard.Conn.Write([]byte(value + "\r\n"))
-	if err != nil {
-		return fmt.Errorf("failed to write to scoreboard: %w", err)
-	}
-	return nil
+	return a.writeScoreboardLocked(defaultScoreboardDevice, func(d ScoreboardDriver) []byte { return d.EncodeLine(1, value) },
+		fmt.Sprintf("'%s'", value))
 }
 
 // --- Debug Functions ---
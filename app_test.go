@@ -0,0 +1,291 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestApp returns a headless demo App with its config in a temporary
+// directory and the "edm" station calibrated for circleType
+func newTestApp(t *testing.T, circleType string) *App {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	a := NewApp()
+	a.SetDemoMode(true)
+	setTestCircle(t, a, circleType)
+	return a
+}
+
+func setTestCircle(t *testing.T, a *App, circleType string) {
+	t.Helper()
+	setStationCircle(t, a, "edm", circleType)
+}
+
+func setStationCircle(t *testing.T, a *App, devType, circleType string) {
+	t.Helper()
+	err := a.SaveCalibration(devType, EDMCalibrationData{
+		DeviceID:           devType,
+		SelectedCircleType: circleType,
+		TargetRadius:       UkaRadiusShot,
+		StationCoordinates: EDMPoint{X: -5, Y: 0},
+		IsCentreSet:        true,
+	})
+	if err != nil {
+		t.Fatalf("SaveCalibration: %v", err)
+	}
+}
+
+// measureDuring measures a demo throw on "edm" and runs during once the
+// station's session has been read, while the reading itself is held back
+func measureDuring(t *testing.T, a *App, during func()) error {
+	t.Helper()
+	reading, resume := make(chan struct{}), make(chan struct{})
+	a.demoThrowHook = func(string) {
+		close(reading)
+		<-resume
+	}
+	defer func() { a.demoThrowHook = nil }()
+	done := make(chan error, 1)
+	go func() {
+		_, err := a.MeasureThrow("edm")
+		done <- err
+	}()
+	<-reading
+	during()
+	close(resume)
+	return <-done
+}
+
+func TestThrowOutsideSessionScopeIsRejected(t *testing.T) {
+	t.Run("circle type", func(t *testing.T) {
+		a := newTestApp(t, "SHOT")
+		if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+			t.Fatalf("StartThrowSession: %v", err)
+		}
+		setTestCircle(t, a, "DISCUS")
+		_, err := a.MeasureThrow("edm")
+		if err == nil || !strings.Contains(err.Error(), "is for SHOT") {
+			t.Fatalf("got error %v, want the session's circle type to be enforced", err)
+		}
+		if n := len(a.throwCoordinates); n != 0 {
+			t.Errorf("%d throws recorded, want none", n)
+		}
+	})
+
+	t.Run("event", func(t *testing.T) {
+		a := newTestApp(t, "SHOT")
+		if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+			t.Fatalf("StartThrowSession: %v", err)
+		}
+		// SetStationEvent refuses while a session runs, so move the station
+		// directly to check measureThrow does not rely on that
+		a.stateMux.Lock()
+		a.stations["edm"].event = "SP Men"
+		a.stateMux.Unlock()
+		_, err := a.MeasureThrow("edm")
+		if err == nil || !strings.Contains(err.Error(), "measuring 'SP Men'") {
+			t.Fatalf("got error %v, want the session's event to be enforced", err)
+		}
+		if n := len(a.throwCoordinates); n != 0 {
+			t.Errorf("%d throws recorded, want none", n)
+		}
+	})
+}
+
+func TestLateThrowJoinsEndedSession(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+		t.Fatalf("StartThrowSession: %v", err)
+	}
+	err := measureDuring(t, a, func() {
+		if _, err := a.EndThrowSession("edm"); err != nil {
+			t.Errorf("EndThrowSession: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("MeasureThrow: %v", err)
+	}
+
+	session, err := a.GetSession("shot-1")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if len(session.Coordinates) != 1 || session.Statistics == nil || session.Statistics.TotalThrows != 1 {
+		t.Fatalf("archived session has %d throws, want the late throw", len(session.Coordinates))
+	}
+	if got := a.throwCoordinates[0].SessionID; got != "shot-1" {
+		t.Errorf("throw session ID = %q, want shot-1", got)
+	}
+	if _, err := a.GetCurrentSession("edm"); err == nil {
+		t.Error("late throw restarted the ended session")
+	}
+}
+
+func TestDuplicateSessionIDRefused(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+		t.Fatalf("StartThrowSession: %v", err)
+	}
+	if err := a.StartThrowSession("edm2", "SHOT", "shot-1"); err == nil {
+		t.Error("reused the ID of a running session")
+	}
+	if _, err := a.EndThrowSession("edm"); err != nil {
+		t.Fatalf("EndThrowSession: %v", err)
+	}
+	if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err == nil {
+		t.Error("reused the ID of an archived session")
+	}
+	if _, ok := a.sessions["shot-1"]; !ok {
+		t.Error("archived session was replaced")
+	}
+}
+
+func TestThrowAfterSessionStops(t *testing.T) {
+	t.Run("ended", func(t *testing.T) {
+		a := newTestApp(t, "SHOT")
+		if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+			t.Fatalf("StartThrowSession: %v", err)
+		}
+		if _, err := a.EndThrowSession("edm"); err != nil {
+			t.Fatalf("EndThrowSession: %v", err)
+		}
+		if _, err := a.MeasureThrow("edm"); err != nil {
+			t.Fatalf("MeasureThrow: %v", err)
+		}
+		if got := a.throwCoordinates[0].SessionID; got != "" {
+			t.Errorf("throw session ID = %q, want none", got)
+		}
+		if n := len(a.sessions["shot-1"].Coordinates); n != 0 {
+			t.Errorf("ended session gained %d throws", n)
+		}
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		a := newTestApp(t, "SHOT")
+		if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+			t.Fatalf("StartThrowSession: %v", err)
+		}
+		err := measureDuring(t, a, func() {
+			if err := a.ClearThrowCoordinates(); err != nil {
+				t.Errorf("ClearThrowCoordinates: %v", err)
+			}
+		})
+		if err != nil {
+			t.Fatalf("MeasureThrow: %v", err)
+		}
+		if n := len(a.throwCoordinates); n != 1 {
+			t.Fatalf("%d throws after clearing, want the one in flight", n)
+		}
+		session, ok := a.sessions["shot-1"]
+		if !ok {
+			t.Fatal("clearing did not archive the running session")
+		}
+		if len(session.Coordinates) != 1 {
+			t.Errorf("archived session has %d throws, want the one in flight", len(session.Coordinates))
+		}
+		if _, err := a.GetCurrentSession("edm"); err == nil {
+			t.Error("station still has a session after clearing")
+		}
+	})
+}
+
+func TestConcurrentDemoStations(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	setStationCircle(t, a, "edm-b", "SHOT")
+
+	const throwsPerStation = 3
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*throwsPerStation+1)
+	for _, devType := range []string{"edm", "edm-b"} {
+		wg.Add(1)
+		go func(devType string) {
+			defer wg.Done()
+			for i := 0; i < throwsPerStation; i++ {
+				if _, err := a.MeasureThrow(devType); err != nil {
+					errs <- err
+				}
+			}
+		}(devType)
+	}
+	// Recalibrating drops the station's simulation while the other measures
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(THROW_DELAY / 2)
+		a.stateMux.Lock()
+		cal := *a.CalibrationStore["edm-b"]
+		a.stateMux.Unlock()
+		if err := a.SaveCalibration("edm-b", cal); err != nil {
+			errs <- err
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if n := len(a.throwCoordinates); n != 2*throwsPerStation {
+		t.Errorf("%d throws recorded, want %d", n, 2*throwsPerStation)
+	}
+}
+
+func TestClearingSyncsEndedSessions(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	a.sync.mu.Lock()
+	a.sync.config = SyncConfig{Enabled: true, Role: SyncRoleStation}
+	a.sync.mu.Unlock()
+	if err := a.StartThrowSession("edm", "SHOT", "shot-1"); err != nil {
+		t.Fatalf("StartThrowSession: %v", err)
+	}
+	if err := a.ClearThrowCoordinates(); err != nil {
+		t.Fatalf("ClearThrowCoordinates: %v", err)
+	}
+
+	a.sync.mu.Lock()
+	defer a.sync.mu.Unlock()
+	sessions := a.sync.outbox.Sessions
+	if len(sessions) == 0 {
+		t.Fatal("cleared session was not queued for sync")
+	}
+	if last := sessions[len(sessions)-1]; last.SessionID != "shot-1" || last.EndTime == nil {
+		t.Errorf("last queued session is %q ended %v, want shot-1 ended", last.SessionID, last.EndTime)
+	}
+}
+
+func TestGetSessionReturnsCopy(t *testing.T) {
+	a := newTestApp(t, "SHOT")
+	if err := a.StartTrainingSession("edm", "SHOT", "shot-1", []string{"7", "8"}, "6kg"); err != nil {
+		t.Fatalf("StartTrainingSession: %v", err)
+	}
+	if _, err := a.MeasureThrow("edm"); err != nil {
+		t.Fatalf("MeasureThrow: %v", err)
+	}
+	if _, err := a.EndThrowSession("edm"); err != nil {
+		t.Fatalf("EndThrowSession: %v", err)
+	}
+
+	session, err := a.GetSession("shot-1")
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	session.Coordinates[0].Distance = 99
+	session.Roster[0] = "99"
+	session.Statistics.TotalThrows = 99
+	session.Statistics.ByAthlete["7"].TotalThrows = 99
+	*session.EndTime = time.Time{}
+
+	archived := a.sessions["shot-1"]
+	if archived.Coordinates[0].Distance == 99 || archived.Roster[0] == "99" || archived.EndTime.IsZero() {
+		t.Error("changing the returned session changed the archive")
+	}
+	if archived.Statistics.TotalThrows == 99 || archived.Statistics.ByAthlete["7"].TotalThrows == 99 {
+		t.Error("changing the returned statistics changed the archive")
+	}
+}
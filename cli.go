@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Headless commands. Anything else on the command line starts the desktop UI.
+var cliCommands = map[string]func(args []string) int{
+	"serve":   cliServe,
+	"measure": cliMeasure,
+	"ports":   cliPorts,
+}
+
+// runCLI runs a headless command. ok is false when args do not name one.
+func runCLI(args []string) (exitCode int, ok bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(os.Stderr, cliUsage)
+		return 0, true
+	}
+	cmd, ok := cliCommands[args[0]]
+	if !ok {
+		return 0, false
+	}
+	return cmd(args[1:]), true
+}
+
+const cliUsage = `Usage: polyfield [command] [flags]
+
+With no command the desktop app starts. Commands:
+
+  serve     Connect devices and run the HTTP API until interrupted
+  measure   Connect devices and read commands from stdin, one JSON result per line
+  ports     List serial ports as JSON
+
+Device flags take a serial port (COM3, /dev/ttyUSB0) or host:port.
+Run "polyfield <command> -h" for its flags.
+`
+
+// cliOptions are the flags shared by serve and measure
+type cliOptions struct {
+	demo       bool
+	station    string
+	edm        string
+	wind       string
+	scoreboard string
+	preset     string
+	listen     string
+	token      string
+}
+
+func (o *cliOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&o.demo, "demo", false, "Use demo mode instead of hardware")
+	fs.StringVar(&o.station, "station", "edm", "EDM device name, e.g. edm-discus when running several")
+	fs.StringVar(&o.edm, "edm", "", "EDM port or host:port")
+	fs.StringVar(&o.wind, "wind", "", "Wind gauge port or host:port")
+	fs.StringVar(&o.scoreboard, "scoreboard", "", "Scoreboard port or host:port")
+	fs.StringVar(&o.preset, "preset", "", "Serial preset name for serial devices (default 9600 8-N-1)")
+	fs.StringVar(&o.token, "token", "", "API token (generated if empty)")
+}
+
+// startHeadless creates the App, connects the requested and bound devices
+// and starts the API if listen is set
+func startHeadless(o cliOptions) (*App, error) {
+	app := NewApp()
+	app.SetDemoMode(o.demo)
+	for _, msg := range app.ConnectBoundDevices() {
+		log.Printf("Auto-connect: %s", msg)
+	}
+	for devType, address := range map[string]string{o.station: o.edm, "wind": o.wind, defaultScoreboardDevice: o.scoreboard} {
+		if address == "" {
+			continue
+		}
+		msg, err := app.connectCLIDevice(devType, address, o.preset)
+		if err != nil {
+			app.wailsShutdown(context.Background())
+			return nil, fmt.Errorf("could not connect %s: %w", devType, err)
+		}
+		log.Print(msg)
+	}
+	if o.listen != "" {
+		status, err := app.StartAPIServer(APIServerConfig{ListenAddress: o.listen, Token: o.token})
+		if err != nil {
+			app.wailsShutdown(context.Background())
+			return nil, err
+		}
+		log.Printf("API token: %s", status.Token)
+	}
+	return app, nil
+}
+
+// connectCLIDevice treats host:port as a network device and anything else as
+// a serial port
+func (a *App) connectCLIDevice(devType, address, preset string) (string, error) {
+	if host, portStr, err := net.SplitHostPort(address); err == nil && host != "" {
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return "", fmt.Errorf("invalid port in '%s'", address)
+		}
+		return a.ConnectNetworkDevice(devType, host, port)
+	}
+	if preset != "" {
+		return a.ConnectSerialDeviceWithPreset(devType, address, preset)
+	}
+	return a.ConnectSerialDevice(devType, address)
+}
+
+// --- Commands ---
+
+func cliServe(args []string) int {
+	var o cliOptions
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	o.register(fs)
+	fs.StringVar(&o.listen, "listen", "", "API listen address (default from saved config, else "+defaultAPIListenAddr+")")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if o.listen == "" {
+		o.listen = loadAPIServerConfig().ListenAddress
+	}
+	if o.token == "" {
+		o.token = loadAPIServerConfig().Token
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	app, err := startHeadless(o)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return 1
+	}
+	<-ctx.Done()
+	log.Printf("Shutting down")
+	app.wailsShutdown(context.Background())
+	return 0
+}
+
+func cliMeasure(args []string) int {
+	var o cliOptions
+	fs := flag.NewFlagSet("measure", flag.ContinueOnError)
+	o.register(fs)
+	fs.StringVar(&o.listen, "listen", "", "Also run the HTTP API on this address")
+	circle := fs.String("circle", "", "Circle type: SHOT, DISCUS, HAMMER or JAVELIN_ARC")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	app, err := startHeadless(o)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return 1
+	}
+	defer app.wailsShutdown(context.Background())
+	// Ctrl-C interrupts a measurement in progress before exiting
+	context.AfterFunc(ctx, func() { app.CancelMeasurement(o.station) })
+
+	out := json.NewEncoder(os.Stdout)
+	out.SetEscapeHTML(false)
+	if *circle != "" {
+		out.Encode(cliReply("circle")(app.cliSetCircle(o.station, *circle)))
+	}
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case line, ok := <-lines:
+			if !ok {
+				return 0
+			}
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			if fields[0] == "quit" || fields[0] == "exit" {
+				return 0
+			}
+			out.Encode(app.runCLICommand(o.station, fields))
+		}
+	}
+}
+
+func cliPorts(args []string) int {
+	app := NewApp()
+	ports, err := app.ListSerialPortDetails()
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return 1
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(ports)
+	return 0
+}
+
+// --- Measure session commands ---
+
+type cliResult struct {
+	Command string      `json:"command"`
+	OK      bool        `json:"ok"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// cliReply builds the result line from an App method returning (T, error)
+func cliReply(command string) func(result interface{}, err error) cliResult {
+	return func(result interface{}, err error) cliResult {
+		if err != nil {
+			return cliResult{Command: command, Error: err.Error()}
+		}
+		return cliResult{Command: command, OK: true, Result: result}
+	}
+}
+
+const cliMeasureHelp = `circle <SHOT|DISCUS|HAMMER|JAVELIN_ARC>
+centre
+edge
+throw
+foul
+pass
+wind
+athlete <bib> [attempt] [name...]
+event [name]
+session start <circleType> [id] | session end | session list
+training <circleType> <bib,bib,...> [implement]
+next
+implement [name]
+tags [tag...]
+standings [circleType]
+status
+quit`
+
+// runCLICommand executes one line of a measure session against the station's EDM
+func (a *App) runCLICommand(devType string, fields []string) cliResult {
+	cmd, args := fields[0], fields[1:]
+	switch cmd {
+	case "help":
+		return cliReply(cmd)(strings.Split(cliMeasureHelp, "\n"), nil)
+	case "circle":
+		if len(args) != 1 {
+			return cliReply(cmd)(nil, fmt.Errorf("usage: circle <type>"))
+		}
+		return cliReply(cmd)(a.cliSetCircle(devType, args[0]))
+	case "centre", "center":
+		return cliReply(cmd)(a.SetCircleCentre(devType))
+	case "edge":
+		return cliReply(cmd)(a.VerifyCircleEdge(devType))
+	case "throw":
+		return cliReply(cmd)(a.MeasureThrow(devType))
+	case "foul":
+		return cliReply(cmd)(nil, a.RecordFoul(devType))
+	case "pass":
+		return cliReply(cmd)(nil, a.RecordPass(devType))
+	case "wind":
+		return cliReply(cmd)(a.MeasureWind("wind"))
+	case "athlete":
+		if len(args) == 0 {
+			return cliReply(cmd)(nil, fmt.Errorf("usage: athlete <bib> [attempt] [name...]"))
+		}
+		attempt := 0
+		rest := args[1:]
+		if len(rest) > 0 {
+			if n, err := strconv.Atoi(rest[0]); err == nil {
+				attempt, rest = n, rest[1:]
+			}
+		}
+		if err := a.SetCurrentAthlete(devType, args[0], strings.Join(rest, " "), attempt); err != nil {
+			return cliReply(cmd)(nil, err)
+		}
+		return cliReply(cmd)(a.GetScoreboardState(devType), nil)
+	case "event":
+		if err := a.SetStationEvent(devType, strings.Join(args, " ")); err != nil {
+			return cliReply(cmd)(nil, err)
+		}
+		return cliReply(cmd)(a.ListStartLists(), nil)
+	case "session":
+		switch {
+		case len(args) >= 2 && args[0] == "start":
+			id := ""
+			if len(args) > 2 {
+				id = args[2]
+			}
+			return cliReply(cmd)(nil, a.StartThrowSession(devType, strings.ToUpper(args[1]), id))
+		case len(args) == 1 && args[0] == "end":
+			return cliReply(cmd)(a.EndThrowSession(devType))
+		case len(args) == 1 && args[0] == "list":
+			return cliReply(cmd)(a.ListSessions(), nil)
+		}
+		return cliReply(cmd)(nil, fmt.Errorf("usage: session start <circleType> [id] | session end | session list"))
+	case "training":
+		if len(args) < 2 {
+			return cliReply(cmd)(nil, fmt.Errorf("usage: training <circleType> <bib,bib,...> [implement]"))
+		}
+		if err := a.StartTrainingSession(devType, strings.ToUpper(args[0]), "", strings.Split(args[1], ","), strings.Join(args[2:], " ")); err != nil {
+			return cliReply(cmd)(nil, err)
+		}
+		return cliReply(cmd)(a.GetScoreboardState(devType), nil)
+	case "next":
+		return cliReply(cmd)(a.NextTrainingAthlete(devType))
+	case "implement":
+		return cliReply(cmd)(nil, a.SetTrainingImplement(devType, strings.Join(args, " ")))
+	case "tags":
+		return cliReply(cmd)(nil, a.SetTrainingTags(devType, args))
+	case "standings":
+		cal, _ := a.GetCalibration(devType)
+		circleType := cal.SelectedCircleType
+		if len(args) > 0 {
+			circleType = strings.ToUpper(args[0])
+		}
+		return cliReply(cmd)(a.GetStandings(devType, circleType), nil)
+	case "status":
+		return cliReply(cmd)(a.GetDeviceStatuses(), nil)
+	}
+	return cliReply(cmd)(nil, fmt.Errorf("unknown command '%s' (try help)", cmd))
+}
+
+// cliSetCircle selects the circle type and its UKA radius, keeping the
+// station position but clearing any edge verification
+func (a *App) cliSetCircle(devType, circleType string) (*EDMCalibrationData, error) {
+	circleType = strings.ToUpper(circleType)
+	radius, ok := ukaCircleRadius[circleType]
+	if !ok {
+		return nil, fmt.Errorf("unknown circle type '%s'", circleType)
+	}
+	cal, err := a.GetCalibration(devType)
+	if err != nil {
+		return nil, err
+	}
+	updated := *cal
+	updated.SelectedCircleType = circleType
+	updated.TargetRadius = radius
+	updated.EdgeVerificationResult = nil
+	if err := a.SaveCalibration(devType, updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const configDirName = "PolyField"
+
+// configFilePath returns the path of a file in the per-user PolyField
+// configuration directory, creating the directory if needed
+func configFilePath(name string) (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not locate config directory: %w", err)
+	}
+	dir := filepath.Join(base, configDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("could not create config directory: %w", err)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// loadJSONConfig reads a config file into v. A missing file is not an error
+// and leaves v untouched.
+func loadJSONConfig(name string, v interface{}) error {
+	path, err := configFilePath(name)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// saveJSONConfig writes v to a config file via a temporary file so a crash
+// mid-write cannot leave a truncated file behind
+func saveJSONConfig(name string, v interface{}) error {
+	path, err := configFilePath(name)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVExportOptions controls ExportThrowCoordinatesAsCSVWithOptions. The zero
+// value gives the original export: default columns, metres, "." and UTC.
+type CSVExportOptions struct {
+	Columns          []string `json:"columns"`          // Column keys in order, empty for csvDefaultColumns
+	Units            string   `json:"units"`            // "m" or "cm"
+	DecimalSeparator string   `json:"decimalSeparator"` // "." or ","; "," also switches the delimiter to ";"
+	TimeZone         string   `json:"timeZone"`         // IANA name, "Local" or "UTC"
+}
+
+// csvColumn is one exportable field: its header and how to format it
+type csvColumn struct {
+	header string
+	value  func(coord ThrowCoordinate, f csvFormatter) string
+}
+
+// csvFormatter applies the unit, separator and zone choices
+type csvFormatter struct {
+	scale     float64 // Metres to output unit
+	separator string
+	location  *time.Location
+}
+
+// length formats a distance; decimals is for metres, two fewer in cm
+func (f csvFormatter) length(metres float64, decimals int) string {
+	if f.scale != 1 {
+		decimals = max(decimals-2, 0)
+	}
+	s := strconv.FormatFloat(metres*f.scale, 'f', decimals, 64)
+	return strings.Replace(s, ".", f.separator, 1)
+}
+
+var csvColumns = map[string]csvColumn{
+	"x":          {"X", func(c ThrowCoordinate, f csvFormatter) string { return f.length(c.X, 6) }},
+	"y":          {"Y", func(c ThrowCoordinate, f csvFormatter) string { return f.length(c.Y, 6) }},
+	"distance":   {"Distance", func(c ThrowCoordinate, f csvFormatter) string { return f.length(c.Distance, 3) }},
+	"circleType": {"CircleType", func(c ThrowCoordinate, f csvFormatter) string { return c.CircleType }},
+	"timestamp": {"Timestamp", func(c ThrowCoordinate, f csvFormatter) string {
+		return c.Timestamp.In(f.location).Format("2006-01-02T15:04:05.000Z07:00")
+	}},
+	"athleteId":        {"AthleteID", func(c ThrowCoordinate, f csvFormatter) string { return c.AthleteID }},
+	"competitionRound": {"CompetitionRound", func(c ThrowCoordinate, f csvFormatter) string { return c.CompetitionRound }},
+	"edmReading":       {"EDMReading", func(c ThrowCoordinate, f csvFormatter) string { return c.EDMReading }},
+	"id":               {"ID", func(c ThrowCoordinate, f csvFormatter) string { return c.ID }},
+	"origin":           {"Origin", func(c ThrowCoordinate, f csvFormatter) string { return c.Origin }},
+	"station":          {"Station", func(c ThrowCoordinate, f csvFormatter) string { return c.Station }},
+	"event":            {"Event", func(c ThrowCoordinate, f csvFormatter) string { return c.Event }},
+	"attempt": {"Attempt", func(c ThrowCoordinate, f csvFormatter) string {
+		if c.Attempt == 0 {
+			return ""
+		}
+		return strconv.Itoa(c.Attempt)
+	}},
+}
+
+// csvDefaultColumns is the layout the export has always had
+var csvDefaultColumns = []string{"x", "y", "distance", "circleType", "timestamp", "athleteId", "competitionRound", "edmReading"}
+
+// csvAllColumns is every column, defaults first
+var csvAllColumns = append(csvDefaultColumns[:len(csvDefaultColumns):len(csvDefaultColumns)], "id", "origin", "station", "event", "attempt")
+
+// newCSVFormatter validates the options
+func newCSVFormatter(opts CSVExportOptions) (csvFormatter, error) {
+	f := csvFormatter{scale: 1, separator: ".", location: time.UTC}
+	switch opts.Units {
+	case "", "m":
+	case "cm":
+		f.scale = 100
+	default:
+		return f, fmt.Errorf("unknown units '%s' (use m or cm)", opts.Units)
+	}
+	switch opts.DecimalSeparator {
+	case "", ".":
+	case ",":
+		f.separator = ","
+	default:
+		return f, fmt.Errorf("decimal separator must be '.' or ','")
+	}
+	if opts.TimeZone != "" {
+		loc, err := time.LoadLocation(opts.TimeZone)
+		if err != nil {
+			return f, fmt.Errorf("unknown time zone '%s': %w", opts.TimeZone, err)
+		}
+		f.location = loc
+	}
+	return f, nil
+}
+
+// encodeCoordinatesCSV writes the coordinates with the chosen columns
+func encodeCoordinatesCSV(coordinates []ThrowCoordinate, opts CSVExportOptions) (string, error) {
+	f, err := newCSVFormatter(opts)
+	if err != nil {
+		return "", err
+	}
+	keys := opts.Columns
+	if len(keys) == 0 {
+		keys = csvDefaultColumns
+	}
+	columns := make([]csvColumn, len(keys))
+	header := make([]string, len(keys))
+	for i, key := range keys {
+		column, ok := csvColumns[key]
+		if !ok {
+			return "", fmt.Errorf("unknown column '%s'", key)
+		}
+		columns[i], header[i] = column, column.header
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if f.separator == "," {
+		w.Comma = ';'
+	}
+	w.Write(header)
+	record := make([]string, len(columns))
+	for _, coord := range coordinates {
+		for i, column := range columns {
+			record[i] = column.value(coord, f)
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("could not write CSV: %w", err)
+	}
+	return b.String(), nil
+}
+
+// --- Wails Bindable CSV Export Functions ---
+
+// ExportThrowCoordinatesAsCSV exports every throw with the default columns,
+// in metres with UTC timestamps
+func (a *App) ExportThrowCoordinatesAsCSV() (string, error) {
+	return a.ExportThrowCoordinatesAsCSVWithOptions(CSVExportOptions{})
+}
+
+// ExportThrowCoordinatesAsCSVWithOptions exports every throw with the chosen
+// columns, units, decimal separator and time zone
+func (a *App) ExportThrowCoordinatesAsCSVWithOptions(opts CSVExportOptions) (string, error) {
+	a.stateMux.Lock()
+	coordinates := make([]ThrowCoordinate, len(a.throwCoordinates))
+	copy(coordinates, a.throwCoordinates)
+	a.stateMux.Unlock()
+
+	data, err := encodeCoordinatesCSV(coordinates, opts)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Exported %d coordinates as CSV", len(coordinates))
+	return data, nil
+}
+
+// GetCSVExportColumns lists the column keys ExportThrowCoordinatesAsCSVWithOptions accepts
+func (a *App) GetCSVExportColumns() []string {
+	return csvAllColumns
+}
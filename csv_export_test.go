@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // Time zone goldens must not depend on the host's zoneinfo
+)
+
+var updateGoldens = flag.Bool("update", false, "rewrite testdata goldens")
+
+// csvFixture covers awkward text fields next to plain ones
+func csvFixture() []ThrowCoordinate {
+	at := time.Date(2026, 1, 17, 9, 30, 15, 250_000_000, time.UTC)
+	return []ThrowCoordinate{
+		{
+			X: 12.3456789, Y: -3.25, Distance: 12.769, CircleType: "SHOT", Timestamp: at,
+			AthleteID: "101", CompetitionRound: "Final", EDMReading: "12769 1234500 0900000",
+			ID: "a1", Origin: "field-1", Station: "edm-1", Event: "SP Men", Attempt: 1,
+		},
+		{
+			X: 40.5, Y: 2.125, Distance: 40.6, CircleType: "DISCUS", Timestamp: at.Add(90 * time.Second),
+			AthleteID: `Smith, "Jo"`, CompetitionRound: "Pool A\nFlight 2",
+			ID: "a2", Origin: "field-1", Station: "edm-2", Event: "DT Women",
+		},
+	}
+}
+
+func TestEncodeCoordinatesCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CSVExportOptions
+	}{
+		{"default", CSVExportOptions{}},
+		{"centimetres", CSVExportOptions{Units: "cm"}},
+		{"decimal_comma", CSVExportOptions{DecimalSeparator: ","}},
+		{"time_zone", CSVExportOptions{TimeZone: "Australia/Adelaide"}},
+		{"columns", CSVExportOptions{Columns: []string{"event", "attempt", "athleteId", "distance", "id"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeCoordinatesCSV(csvFixture(), tt.opts)
+			if err != nil {
+				t.Fatalf("encodeCoordinatesCSV: %v", err)
+			}
+			golden := filepath.Join("testdata", "csv_export_"+tt.name+".csv")
+			if *updateGoldens {
+				if err := os.WriteFile(golden, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden (run with -update to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("output differs from %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeCoordinatesCSVErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CSVExportOptions
+		want string
+	}{
+		{"unknown column", CSVExportOptions{Columns: []string{"x", "speed"}}, "unknown column 'speed'"},
+		{"unknown units", CSVExportOptions{Units: "ft"}, "unknown units 'ft'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encodeCoordinatesCSV(csvFixture(), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("got error %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
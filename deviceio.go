@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+const (
+	serialPollSlice  = 100 * time.Millisecond // Granularity of serial read timeouts
+	tcpDrainWindow   = 20 * time.Millisecond  // How long to wait for stale TCP input
+	maxDrainedBytes  = 4096
+	defaultIOTimeout = edmReadTimeout
+)
+
+var errDeviceClosed = errors.New("device connection closed")
+
+// timeoutError is returned when a read deadline passes. It satisfies
+// net.Error so isConnectionFailure treats serial and TCP timeouts alike.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "read timed out" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+// deadlineReader gives serial ports the same absolute read deadline TCP
+// sockets have. Serial reads return (0, nil) when their timeout slice
+// elapses, so Read keeps polling until the deadline or until the job's
+// context is cancelled.
+type deadlineReader struct {
+	conn     io.ReadWriteCloser
+	deadline time.Time
+	ctx      context.Context
+}
+
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if _, ok := r.conn.(serial.Port); !ok {
+		n, err := r.conn.Read(p)
+		var netErr net.Error
+		if err != nil && errors.As(err, &netErr) && netErr.Timeout() {
+			if r.ctx != nil && r.ctx.Err() != nil {
+				return n, r.ctx.Err()
+			}
+			return n, timeoutError{}
+		}
+		return n, err
+	}
+	for {
+		if r.ctx != nil && r.ctx.Err() != nil {
+			return 0, r.ctx.Err()
+		}
+		if !r.deadline.IsZero() && time.Now().After(r.deadline) {
+			return 0, timeoutError{}
+		}
+		n, err := r.conn.Read(p)
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+// deviceIO owns a device connection. All request/response traffic runs as
+// jobs on a single goroutine so two callers can never interleave writes or
+// consume each other's replies, and the buffered reader persists between
+// transactions so no bytes are lost.
+type deviceIO struct {
+	conn     io.ReadWriteCloser
+	dr       *deadlineReader
+	reader   *bufio.Reader
+	jobs     chan ioJob
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+type ioJob struct {
+	ctx    context.Context
+	fn     func(dio *deviceIO) error
+	result chan error
+}
+
+func newDeviceIO(conn io.ReadWriteCloser) *deviceIO {
+	dr := &deadlineReader{conn: conn}
+	if port, ok := conn.(serial.Port); ok {
+		port.SetReadTimeout(serialPollSlice)
+	}
+	d := &deviceIO{
+		conn:   conn,
+		dr:     dr,
+		reader: bufio.NewReader(dr),
+		jobs:   make(chan ioJob),
+		done:   make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+func (d *deviceIO) run() {
+	for {
+		select {
+		case job := <-d.jobs:
+			job.result <- d.runJob(job)
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// runJob executes one job with its context wired into reads. For TCP a
+// cancellation forces the pending read to return by expiring its deadline.
+func (d *deviceIO) runJob(job ioJob) error {
+	if err := job.ctx.Err(); err != nil {
+		return err // Cancelled while queued
+	}
+	d.dr.ctx = job.ctx
+	defer func() { d.dr.ctx = nil }()
+	if conn, ok := d.conn.(net.Conn); ok {
+		stop := context.AfterFunc(job.ctx, func() { conn.SetReadDeadline(time.Now()) })
+		defer stop()
+	}
+	return job.fn(d)
+}
+
+// sleep pauses within a job, returning early if the job is cancelled
+func (d *deviceIO) sleep(duration time.Duration) error {
+	return sleepCtx(d.dr.ctx, duration)
+}
+
+// sleepCtx is time.Sleep that gives up when ctx is cancelled
+func sleepCtx(ctx context.Context, duration time.Duration) error {
+	if ctx == nil {
+		time.Sleep(duration)
+		return nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// streamReader returns a reader for devices that push data unprompted (the
+// wind gauge). It blocks until data arrives or the connection closes.
+func (d *deviceIO) streamReader() io.Reader {
+	return &deadlineReader{conn: d.conn}
+}
+
+// stop ends the worker. The connection itself is closed by the caller.
+func (d *deviceIO) stop() {
+	d.stopOnce.Do(func() { close(d.done) })
+}
+
+// do runs fn with exclusive use of the connection and waits for it to
+// finish. Cancelling ctx abandons a queued job or interrupts a running one.
+func (d *deviceIO) do(ctx context.Context, fn func(dio *deviceIO) error) error {
+	result := make(chan error, 1)
+	select {
+	case d.jobs <- ioJob{ctx: ctx, fn: fn, result: result}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.done:
+		return errDeviceClosed
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-d.done:
+		return errDeviceClosed
+	}
+}
+
+// flushInput discards anything left over from an earlier exchange, e.g. a
+// late reply to a read that already timed out
+func (d *deviceIO) flushInput() {
+	d.reader.Discard(d.reader.Buffered())
+	if port, ok := d.conn.(serial.Port); ok {
+		port.ResetInputBuffer()
+		return
+	}
+	d.dr.deadline = time.Now().Add(tcpDrainWindow)
+	if conn, ok := d.conn.(net.Conn); ok {
+		conn.SetReadDeadline(d.dr.deadline)
+		defer conn.SetReadDeadline(time.Time{})
+	}
+	buf := make([]byte, 256)
+	for drained := 0; drained < maxDrainedBytes; {
+		n, err := d.dr.Read(buf)
+		drained += n
+		if err != nil || n == 0 {
+			break
+		}
+	}
+	d.dr.deadline = time.Time{}
+}
+
+// setDeadline bounds the reads in the current job
+func (d *deviceIO) setDeadline(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultIOTimeout
+	}
+	d.dr.deadline = time.Now().Add(timeout)
+	if conn, ok := d.conn.(net.Conn); ok {
+		conn.SetReadDeadline(d.dr.deadline)
+	}
+}
+
+func (d *deviceIO) clearDeadline() {
+	d.dr.deadline = time.Time{}
+	if conn, ok := d.conn.(net.Conn); ok {
+		conn.SetReadDeadline(time.Time{})
+	}
+}
+
+func (d *deviceIO) write(b []byte) error {
+	_, err := d.conn.Write(b)
+	return err
+}
+
+// readLine reads one CR/LF terminated response within timeout
+func (d *deviceIO) readLine(timeout time.Duration) (string, error) {
+	d.setDeadline(timeout)
+	defer d.clearDeadline()
+	return d.reader.ReadString('\n')
+}
+
+// readByte reads a single byte within timeout
+func (d *deviceIO) readByte(timeout time.Duration) (byte, error) {
+	d.setDeadline(timeout)
+	defer d.clearDeadline()
+	return d.reader.ReadByte()
+}
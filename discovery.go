@@ -0,0 +1,352 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+const asciiENQ = 0x05
+
+var errProbeTimeout = errors.New("no response within probe timeout")
+
+const (
+	defaultProbeTimeout  = 1500 * time.Millisecond
+	networkDialTimeout   = 300 * time.Millisecond
+	maxDiscoveryHosts    = 1024
+	networkProbeWorkers  = 64
+	defaultDiscoveryPort = 10001
+)
+
+// DiscoveryOptions controls which transports are probed
+type DiscoveryOptions struct {
+	IncludeSerial bool   `json:"includeSerial"`
+	Subnet        string `json:"subnet"`    // CIDR, e.g. 192.168.1.0/24; empty skips network scan
+	Ports         []int  `json:"ports"`     // TCP ports to try on each host
+	TimeoutMs     int    `json:"timeoutMs"` // Per-probe wait for a response
+}
+
+// DiscoveredDevice is an instrument that answered an identification exchange
+type DiscoveredDevice struct {
+	DeviceType     string          `json:"deviceType"`
+	Model          string          `json:"model"`
+	ConnectionType string          `json:"connectionType"`
+	Address        string          `json:"address"`
+	Settings       *SerialSettings `json:"settings,omitempty"`
+	PresetName     string          `json:"presetName,omitempty"`
+	Detail         string          `json:"detail"` // What the device sent back
+}
+
+// deviceProbe is one driver's identification exchange. identify returns a
+// model description and the response that identified it.
+type deviceProbe struct {
+	deviceType string
+	identify   func(conn io.ReadWriter, timeout time.Duration) (model, detail string, ok bool)
+}
+
+func (a *App) deviceProbes() []deviceProbe {
+	return []deviceProbe{
+		{deviceType: "wind", identify: a.identifyWindGauge},
+		{deviceType: "edm", identify: identifyEDM},
+		{deviceType: "scoreboard", identify: identifyScoreboard},
+	}
+}
+
+// identifyWindGauge listens passively, since gauges stream unprompted
+func (a *App) identifyWindGauge(conn io.ReadWriter, timeout time.Duration) (string, string, bool) {
+	line, err := readLineWithTimeout(conn, timeout)
+	if err != nil {
+		return "", "", false
+	}
+	if _, ok := a.parseWindResponse(line); ok {
+		return "Streaming wind gauge", line, true
+	}
+	return "", "", false
+}
+
+// identifyEDM triggers a single read and checks the reply parses
+func identifyEDM(conn io.ReadWriter, timeout time.Duration) (string, string, bool) {
+	if _, err := conn.Write(edmReadCommand); err != nil {
+		return "", "", false
+	}
+	line, err := readLineWithTimeout(conn, timeout)
+	if err != nil {
+		return "", "", false
+	}
+	if _, err := parseEDMResponseString(line); err != nil {
+		return "", "", false
+	}
+	return "EDM (SD/VAz/HAR response)", line, true
+}
+
+// identifyScoreboard sends a single ENQ and looks for an ACK. Nothing that
+// would change a display is written, since the port may be something else.
+// Boards that do not answer ENQ cannot be detected.
+func identifyScoreboard(conn io.ReadWriter, timeout time.Duration) (string, string, bool) {
+	if _, err := conn.Write([]byte{asciiENQ}); err != nil {
+		return "", "", false
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		b, err := readProbeByte(conn, deadline)
+		if err != nil {
+			return "", "", false
+		}
+		if b == asciiACK {
+			return "Scoreboard (acknowledges ENQ)", "ACK", true
+		}
+	}
+}
+
+// readLineWithTimeout reads up to a newline or until timeout elapses
+func readLineWithTimeout(conn io.ReadWriter, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	var line []byte
+	for {
+		b, err := readProbeByte(conn, deadline)
+		if err != nil {
+			return "", err
+		}
+		if b == '\n' {
+			return string(line), nil
+		}
+		line = append(line, b)
+	}
+}
+
+// readProbeByte reads one byte, giving up at deadline. The read timeout is
+// set before every read, as nothing else bounds a silent port or host.
+func readProbeByte(conn io.Reader, deadline time.Time) (byte, error) {
+	rwc, settable := conn.(io.ReadWriteCloser)
+	buf := make([]byte, 1)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return 0, errProbeTimeout
+		}
+		if settable {
+			setConnReadTimeout(rwc, remaining)
+		}
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		if n == 1 {
+			return buf[0], nil
+		}
+		// Serial read timed out with nothing received, check the deadline
+	}
+}
+
+// runProbe runs one identification exchange and then clears the read
+// timeout it left on the connection
+func runProbe(probe deviceProbe, conn io.ReadWriteCloser, timeout time.Duration) (string, string, bool) {
+	defer setConnReadTimeout(conn, 0)
+	return probe.identify(conn, timeout)
+}
+
+// candidateSerialPresets returns one preset per distinct device type and line
+// setting, taken from the built-in and user presets. Scoreboard presets come
+// last so a port is only probed as a scoreboard once nothing else answered.
+func (a *App) candidateSerialPresets() []SerialPreset {
+	seen := make(map[string]bool)
+	var candidates []SerialPreset
+	for _, preset := range a.ListSerialPresets() {
+		key := preset.DeviceType + " " + preset.Settings.String()
+		if !seen[key] {
+			seen[key] = true
+			candidates = append(candidates, preset)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].DeviceType != "scoreboard" && candidates[j].DeviceType == "scoreboard"
+	})
+	return candidates
+}
+
+// discoverSerial opens every free serial port at each candidate setting and
+// runs each probe until one answers
+func (a *App) discoverSerial(timeout time.Duration) []DiscoveredDevice {
+	ports, err := serial.GetPortsList()
+	if err != nil {
+		log.Printf("Discovery: could not list serial ports: %v", err)
+		return nil
+	}
+	inUse := make(map[string]bool)
+	a.stateMux.Lock()
+	for _, dev := range a.devices {
+		if dev.ConnectionType == "serial" {
+			inUse[dev.Address] = true
+		}
+	}
+	a.stateMux.Unlock()
+
+	candidates := a.candidateSerialPresets()
+	probes := a.deviceProbes()
+	var found []DiscoveredDevice
+	for _, portName := range ports {
+		if inUse[portName] {
+			continue
+		}
+	portLoop:
+		for _, preset := range candidates {
+			settings := preset.Settings
+			port, err := openSerialPort(portName, settings)
+			if err != nil {
+				log.Printf("Discovery: could not open %s: %v", portName, err)
+				break
+			}
+			for _, probe := range probes {
+				if probe.deviceType != preset.DeviceType {
+					continue
+				}
+				port.ResetInputBuffer()
+				if model, detail, ok := runProbe(probe, port, timeout); ok {
+					found = append(found, DiscoveredDevice{
+						DeviceType:     probe.deviceType,
+						Model:          model,
+						ConnectionType: "serial",
+						Address:        portName,
+						Settings:       &settings,
+						PresetName:     preset.Name,
+						Detail:         detail,
+					})
+					port.Close()
+					break portLoop
+				}
+			}
+			port.Close()
+		}
+	}
+	return found
+}
+
+// discoverNetwork connects to each host:port in the subnet and runs the probes
+func (a *App) discoverNetwork(subnet string, ports []int, timeout time.Duration) ([]DiscoveredDevice, error) {
+	hosts, err := hostsInSubnet(subnet)
+	if err != nil {
+		return nil, err
+	}
+	probes := a.deviceProbes()
+	type target struct{ address string }
+	targets := make(chan target)
+	var (
+		mu    sync.Mutex
+		found []DiscoveredDevice
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < networkProbeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range targets {
+				if dev, ok := probeNetworkAddress(probes, t.address, timeout); ok {
+					mu.Lock()
+					found = append(found, dev)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, host := range hosts {
+		for _, port := range ports {
+			targets <- target{address: net.JoinHostPort(host, strconv.Itoa(port))}
+		}
+	}
+	close(targets)
+	wg.Wait()
+	return found, nil
+}
+
+func probeNetworkAddress(probes []deviceProbe, address string, timeout time.Duration) (DiscoveredDevice, bool) {
+	for _, probe := range probes {
+		conn, err := net.DialTimeout("tcp", address, networkDialTimeout)
+		if err != nil {
+			return DiscoveredDevice{}, false // Nothing listening, skip the other probes
+		}
+		model, detail, ok := runProbe(probe, conn, timeout)
+		conn.Close()
+		if ok {
+			return DiscoveredDevice{
+				DeviceType:     probe.deviceType,
+				Model:          model,
+				ConnectionType: "network",
+				Address:        address,
+				Detail:         detail,
+			}, true
+		}
+	}
+	return DiscoveredDevice{}, false
+}
+
+// hostsInSubnet expands an IPv4 CIDR into host addresses, excluding the
+// network and broadcast addresses
+func hostsInSubnet(cidr string) ([]string, error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet '%s': %w", cidr, err)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("only IPv4 subnets can be scanned")
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones > 10 {
+		return nil, fmt.Errorf("subnet '%s' too large to scan (max %d hosts)", cidr, maxDiscoveryHosts)
+	}
+	var hosts []string
+	for cur := ipNet.IP.Mask(ipNet.Mask).To4(); ipNet.Contains(cur); cur = nextIP(cur) {
+		hosts = append(hosts, cur.String())
+	}
+	if len(hosts) > 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// --- Wails Bindable Discovery Functions ---
+
+// DiscoverDevices probes serial ports and, if a subnet is given, the local
+// network for known instruments
+func (a *App) DiscoverDevices(options DiscoveryOptions) ([]DiscoveredDevice, error) {
+	timeout := defaultProbeTimeout
+	if options.TimeoutMs > 0 {
+		timeout = time.Duration(options.TimeoutMs) * time.Millisecond
+	}
+	found := make([]DiscoveredDevice, 0)
+	if options.IncludeSerial {
+		found = append(found, a.discoverSerial(timeout)...)
+	}
+	if options.Subnet != "" {
+		ports := options.Ports
+		if len(ports) == 0 {
+			ports = []int{defaultDiscoveryPort}
+		}
+		networkFound, err := a.discoverNetwork(options.Subnet, ports, timeout)
+		if err != nil {
+			return found, err
+		}
+		found = append(found, networkFound...)
+	}
+	log.Printf("Discovery found %d device(s)", len(found))
+	return found, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Wails event names. The frontend subscribes with EventsOn(name, handler).
+const (
+	EventDeviceState        = "device:state"        // DeviceStatus
+	EventEDMReading         = "edm:reading"         // EDMReadingEvent
+	EventEDMRejected        = "edm:rejected"        // EDMRejectedEvent
+	EventCalibrationChanged = "calibration:changed" // CalibrationChangedEvent
+	EventMarkRecorded       = "mark:recorded"       // ThrowCoordinate
+	EventWindSample         = "wind:sample"         // WindSampleEvent
+	EventWindMeasured       = "wind:measured"       // WindSampleEvent
+)
+
+// EDMReadingEvent is a raw response received from the EDM
+type EDMReadingEvent struct {
+	DeviceType      string    `json:"deviceType"`
+	Raw             string    `json:"raw"`
+	SlopeDistanceMm float64   `json:"slopeDistanceMm"`
+	VAzDecimal      float64   `json:"vAzDecimal"`
+	HARDecimal      float64   `json:"harDecimal"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// EDMRejectedEvent explains why a reading was not used
+type EDMRejectedEvent struct {
+	DeviceType string    `json:"deviceType"`
+	Reason     string    `json:"reason"`
+	Raw        string    `json:"raw,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// CalibrationChangedEvent carries the new calibration, nil when reset
+type CalibrationChangedEvent struct {
+	DeviceType  string              `json:"deviceType"`
+	Calibration *EDMCalibrationData `json:"calibration"`
+	Timestamp   time.Time           `json:"timestamp"`
+}
+
+type WindSampleEvent struct {
+	DeviceType string    `json:"deviceType"`
+	Value      float64   `json:"value"`
+	Timestamp  time.Time `json:"timestamp"`
+	Origin     string    `json:"origin,omitempty"` // Set on readings merged from another instance
+}
+
+// pendingEvent is an event raised while stateMux was held
+type pendingEvent struct {
+	name string
+	data interface{}
+}
+
+// emitEvent pushes an event to the frontend. It is a no-op until Wails has
+// started, so the same code paths work headless. Must not be called with
+// stateMux held; use emitEventLocked there.
+func (a *App) emitEvent(name string, data interface{}) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, name, data)
+}
+
+// emitEventLocked queues an event until unlockState releases stateMux, so a
+// slow frontend cannot stall every device worker waiting for the lock.
+// Caller holds stateMux.
+func (a *App) emitEventLocked(name string, data interface{}) {
+	a.pendingEvents = append(a.pendingEvents, pendingEvent{name: name, data: data})
+}
+
+// unlockState releases stateMux, then sends the events queued while it was
+// held, in order
+func (a *App) unlockState() {
+	events := a.pendingEvents
+	a.pendingEvents = nil
+	a.stateMux.Unlock()
+	for _, e := range events {
+		a.emitEvent(e.name, e.data)
+	}
+}
+
+func (a *App) emitEDMRejected(devType, reason, raw string) {
+	a.emitEvent(EventEDMRejected, EDMRejectedEvent{
+		DeviceType: devType,
+		Reason:     reason,
+		Raw:        raw,
+		Timestamp:  time.Now().UTC(),
+	})
+}
+
+// emitCalibrationChangedLocked sends a copy so later edits don't race the
+// frontend. Caller holds stateMux.
+func (a *App) emitCalibrationChangedLocked(devType string, cal *EDMCalibrationData) {
+	var snapshot *EDMCalibrationData
+	if cal != nil {
+		c := *cal
+		snapshot = &c
+		a.sync.enqueue(func(b *SyncBatch) { b.Calibrations = append(b.Calibrations, c) })
+	}
+	a.emitEventLocked(EventCalibrationChanged, CalibrationChangedEvent{
+		DeviceType:  devType,
+		Calibration: snapshot,
+		Timestamp:   time.Now().UTC(),
+	})
+}
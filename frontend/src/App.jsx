@@ -230,12 +230,17 @@ const HeatMapScreen = ({ onNavigate, appState }) => {
             if (error.toString().includes('no coordinates found')) {
                 setHeatmapData({
                     circleType: currentCircleType,
+                    mode: 'grid',
+                    frame: 'raw',
                     gridSize: gridSize,
                     bounds: { minX: -10, maxX: 10, minY: -10, maxY: 10 }, // Default bounds to ensure circle is visible
                     gridWidth: 0,
                     gridHeight: 0,
                     heatmap: [],
+                    maxValue: 0,
                     totalThrows: 0,
+                    outside: 0,
+                    points: [],
                     coordinates: []
                 });
                 setError(null); // Clear error since this is expected behavior
@@ -348,7 +353,8 @@ const HeatMapScreen = ({ onNavigate, appState }) => {
             return;
         }
 
-        const { heatmap, bounds, gridWidth, gridHeight, coordinates } = heatmapData;
+        // main.Heatmap: heatmap[row][column] counts from bounds.minX/minY in cells of gridSize
+        const { heatmap, bounds, gridWidth, gridHeight, gridSize: cellSize, coordinates } = heatmapData;
         
         // Calculate bounds that include ALL data points AND the circle center (0,0)
         let minX = Math.min(bounds.minX, 0);
@@ -385,7 +391,7 @@ const HeatMapScreen = ({ onNavigate, appState }) => {
         const offsetY = margin + (availableHeight - dataHeight * scale) / 2;
 
         // Find max value for color scaling
-        const maxValue = Math.max(...heatmap.flat(), 1); // Ensure at least 1 to avoid division by zero
+        const maxValue = Math.max(heatmapData.maxValue, 1); // Ensure at least 1 to avoid division by zero
         
         // Draw heatmap grid
         for (let y = 0; y < gridHeight; y++) {
@@ -401,10 +407,10 @@ const HeatMapScreen = ({ onNavigate, appState }) => {
                     
                     ctx.fillStyle = `rgba(${red}, ${green}, ${blue}, ${0.3 + 0.7 * intensity})`;
                     
-                    const cellX = offsetX + (bounds.minX + x * gridSize - minX) * scale;
-                    const cellY = offsetY + (maxY - (bounds.minY + y * gridSize) - minY) * scale;
-                    const cellWidth = gridSize * scale;
-                    const cellHeight = gridSize * scale;
+                    const cellX = offsetX + (bounds.minX + x * cellSize - minX) * scale;
+                    const cellY = offsetY + (maxY - (bounds.minY + (y + 1) * cellSize) - minY) * scale; // Top edge, Y up
+                    const cellWidth = cellSize * scale;
+                    const cellHeight = cellSize * scale;
                     
                     ctx.fillRect(cellX, cellY, cellWidth, cellHeight);
                     
@@ -3,52 +3,198 @@
 import {main} from '../models';
 import {context} from '../models';
 
+export function BindDeviceToPort(arg1:string,arg2:string):Promise<main.PortBinding>;
+
+export function CancelMeasurement(arg1:string):Promise<void>;
+
+export function ClearScoreboard(arg1:string):Promise<void>;
+
 export function ClearThrowCoordinates():Promise<void>;
 
+export function ConnectBoundDevices():Promise<Array<string>>;
+
 export function ConnectNetworkDevice(arg1:string,arg2:string,arg3:number):Promise<string>;
 
 export function ConnectSerialDevice(arg1:string,arg2:string):Promise<string>;
 
+export function ConnectSerialDeviceWithPreset(arg1:string,arg2:string,arg3:string):Promise<string>;
+
+export function ConnectSerialDeviceWithSettings(arg1:string,arg2:string,arg3:main.SerialSettings):Promise<string>;
+
 export function DebugCalibrationData(arg1:string):Promise<void>;
 
+export function DeleteSerialPreset(arg1:string):Promise<void>;
+
+export function DeleteSession(arg1:string):Promise<void>;
+
+export function DeleteStartList(arg1:string):Promise<void>;
+
 export function DisconnectDevice(arg1:string):Promise<string>;
 
-export function EndThrowSession():Promise<main.ThrowSession>;
+export function DiscoverDevices(arg1:main.DiscoveryOptions):Promise<Array<main.DiscoveredDevice>>;
+
+export function EndThrowSession(arg1:string):Promise<main.ThrowSession>;
 
-export function ExportHeatmapData(arg1:string,arg2:number):Promise<Record<string, any>>;
+export function ExportAthleteAnalyticsCSV(arg1:string,arg2:string,arg3:number):Promise<string>;
+
+export function ExportDXF(arg1:string):Promise<string>;
+
+export function ExportGeoJSON(arg1:string):Promise<string>;
+
+export function ExportHeatmapData(arg1:string,arg2:number):Promise<main.Heatmap>;
+
+export function ExportResults(arg1:string,arg2:string):Promise<string>;
 
 export function ExportThrowCoordinates():Promise<Array<main.ThrowCoordinate>>;
 
 export function ExportThrowCoordinatesAsCSV():Promise<string>;
 
+export function ExportThrowCoordinatesAsCSVWithOptions(arg1:main.CSVExportOptions):Promise<string>;
+
 export function ExportThrowCoordinatesForCircle(arg1:string):Promise<Array<main.ThrowCoordinate>>;
 
+export function GetAPIServerStatus():Promise<main.APIServerStatus>;
+
+export function GetAthleteAnalytics(arg1:string,arg2:string,arg3:number):Promise<main.AthleteAnalytics>;
+
+export function GetAthletesAnalytics(arg1:string):Promise<Array<main.AthleteAnalytics>>;
+
+export function GetCSVExportColumns():Promise<Array<string>>;
+
 export function GetCalibration(arg1:string):Promise<main.EDMCalibrationData>;
 
-export function GetCurrentSession():Promise<main.ThrowSession>;
+export function GetCompetitionImplements():Promise<main.CompetitionImplements>;
+
+export function GetCurrentSession(arg1:string):Promise<main.ThrowSession>;
+
+export function GetDeviceStatuses():Promise<Array<main.DeviceStatus>>;
+
+export function GetEventResult(arg1:string):Promise<main.EventResult>;
+
+export function GetHeatmap(arg1:main.HeatmapRequest):Promise<main.Heatmap>;
 
 export function GetReliableEDMReading(arg1:string):Promise<main.AveragedEDMReading>;
 
+export function GetScoreboardConfig(arg1:string):Promise<main.ScoreboardConfig>;
+
+export function GetScoreboardState(arg1:string):Promise<main.ScoreboardState>;
+
+export function GetScoreboardStatus(arg1:string):Promise<main.ScoreboardStatus>;
+
+export function GetScoreboardStatuses():Promise<Array<main.ScoreboardStatus>>;
+
+export function GetSession(arg1:string):Promise<main.ThrowSession>;
+
+export function GetStandings(arg1:string,arg2:string):Promise<Array<main.Standing>>;
+
+export function GetSyncConfig():Promise<main.SyncConfig>;
+
+export function GetSyncStatus():Promise<main.SyncStatus>;
+
+export function GetSyncedResults():Promise<main.SyncedResults>;
+
 export function GetThrowStatistics(arg1:string):Promise<main.SessionStatistics>;
 
+export function ImportCSVStartList(arg1:string,arg2:main.CSVColumnMapping,arg3:string):Promise<Array<main.StartList>>;
+
+export function ImportJSONStartList(arg1:string,arg2:string):Promise<Array<main.StartList>>;
+
+export function ImportLynxStartList(arg1:string):Promise<Array<main.StartList>>;
+
+export function ListPortBindings():Promise<Array<main.PortBinding>>;
+
+export function ListResultEvents():Promise<Array<string>>;
+
+export function ListScoreboardLayouts():Promise<Array<main.ScoreboardLayout>>;
+
+export function ListScoreboardProtocols():Promise<Array<main.ScoreboardProtocolInfo>>;
+
+export function ListSerialPortDetails():Promise<Array<main.SerialPortInfo>>;
+
 export function ListSerialPorts():Promise<Array<string>>;
 
+export function ListSerialPresets():Promise<Array<main.SerialPreset>>;
+
+export function ListSessions():Promise<Array<main.ThrowSession>>;
+
+export function ListStartLists():Promise<Array<main.StartList>>;
+
+export function ListStations():Promise<Array<main.StationInfo>>;
+
+export function MeasureSectorCentreline(arg1:string):Promise<main.EDMCalibrationData>;
+
 export function MeasureThrow(arg1:string):Promise<string>;
 
 export function MeasureWind(arg1:string):Promise<string>;
 
+export function MergeSessions(arg1:Array<string>,arg2:string):Promise<main.ThrowSession>;
+
+export function NextTrainingAthlete(arg1:string):Promise<main.ScoreboardState>;
+
+export function PreviewCSVStartList(arg1:string):Promise<main.CSVPreview>;
+
+export function PrintJudgesCard(arg1:string,arg2:number,arg3:boolean,arg4:string):Promise<Array<number>>;
+
+export function PrintResultSheet(arg1:string,arg2:string):Promise<Array<number>>;
+
+export function RecordFoul(arg1:string):Promise<void>;
+
+export function RecordPass(arg1:string):Promise<void>;
+
+export function RenameSession(arg1:string,arg2:string):Promise<void>;
+
 export function ResetCalibration(arg1:string):Promise<void>;
 
 export function SaveCalibration(arg1:string,arg2:main.EDMCalibrationData):Promise<void>;
 
+export function SaveScoreboardLayout(arg1:main.ScoreboardLayout):Promise<void>;
+
+export function SaveSerialPreset(arg1:main.SerialPreset):Promise<void>;
+
 export function SendToScoreboard(arg1:string):Promise<void>;
 
+export function SendToScoreboardLine(arg1:string,arg2:number,arg3:string):Promise<void>;
+
 export function SetCircleCentre(arg1:string):Promise<main.EDMCalibrationData>;
 
+export function SetCompetitionImplement(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetCurrentAthlete(arg1:string,arg2:string,arg3:string,arg4:number):Promise<void>;
+
 export function SetDemoMode(arg1:boolean):Promise<void>;
 
-export function StartThrowSession(arg1:string,arg2:string):Promise<void>;
+export function SetScoreboardBrightness(arg1:string,arg2:number):Promise<void>;
+
+export function SetScoreboardConfig(arg1:string,arg2:main.ScoreboardConfig):Promise<void>;
+
+export function SetScoreboardLayout(arg1:string,arg2:string):Promise<void>;
+
+export function SetStationEvent(arg1:string,arg2:string):Promise<void>;
+
+export function SetStationScoreboard(arg1:string,arg2:string):Promise<void>;
+
+export function SetSyncConfig(arg1:main.SyncConfig):Promise<void>;
+
+export function SetTrainingImplement(arg1:string,arg2:string):Promise<void>;
+
+export function SetTrainingRoster(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function SetTrainingTags(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function StartAPIServer(arg1:main.APIServerConfig):Promise<main.APIServerStatus>;
+
+export function StartThrowSession(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function StartTrainingSession(arg1:string,arg2:string,arg3:string,arg4:Array<string>,arg5:string):Promise<void>;
 
 export function StartWindListener(arg1:string,arg2:context.Context):Promise<void>;
 
+export function StopAPIServer():Promise<void>;
+
+export function SyncNow():Promise<void>;
+
+export function TagThrow(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function UnbindDevice(arg1:string):Promise<void>;
+
 export function VerifyCircleEdge(arg1:string):Promise<main.EDMCalibrationData>;
@@ -2,10 +2,26 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function BindDeviceToPort(arg1, arg2) {
+  return window['go']['main']['App']['BindDeviceToPort'](arg1, arg2);
+}
+
+export function CancelMeasurement(arg1) {
+  return window['go']['main']['App']['CancelMeasurement'](arg1);
+}
+
+export function ClearScoreboard(arg1) {
+  return window['go']['main']['App']['ClearScoreboard'](arg1);
+}
+
 export function ClearThrowCoordinates() {
   return window['go']['main']['App']['ClearThrowCoordinates']();
 }
 
+export function ConnectBoundDevices() {
+  return window['go']['main']['App']['ConnectBoundDevices']();
+}
+
 export function ConnectNetworkDevice(arg1, arg2, arg3) {
   return window['go']['main']['App']['ConnectNetworkDevice'](arg1, arg2, arg3);
 }
@@ -14,22 +30,62 @@ export function ConnectSerialDevice(arg1, arg2) {
   return window['go']['main']['App']['ConnectSerialDevice'](arg1, arg2);
 }
 
+export function ConnectSerialDeviceWithPreset(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ConnectSerialDeviceWithPreset'](arg1, arg2, arg3);
+}
+
+export function ConnectSerialDeviceWithSettings(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ConnectSerialDeviceWithSettings'](arg1, arg2, arg3);
+}
+
 export function DebugCalibrationData(arg1) {
   return window['go']['main']['App']['DebugCalibrationData'](arg1);
 }
 
+export function DeleteSerialPreset(arg1) {
+  return window['go']['main']['App']['DeleteSerialPreset'](arg1);
+}
+
+export function DeleteSession(arg1) {
+  return window['go']['main']['App']['DeleteSession'](arg1);
+}
+
+export function DeleteStartList(arg1) {
+  return window['go']['main']['App']['DeleteStartList'](arg1);
+}
+
 export function DisconnectDevice(arg1) {
   return window['go']['main']['App']['DisconnectDevice'](arg1);
 }
 
-export function EndThrowSession() {
-  return window['go']['main']['App']['EndThrowSession']();
+export function DiscoverDevices(arg1) {
+  return window['go']['main']['App']['DiscoverDevices'](arg1);
+}
+
+export function EndThrowSession(arg1) {
+  return window['go']['main']['App']['EndThrowSession'](arg1);
+}
+
+export function ExportAthleteAnalyticsCSV(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExportAthleteAnalyticsCSV'](arg1, arg2, arg3);
+}
+
+export function ExportDXF(arg1) {
+  return window['go']['main']['App']['ExportDXF'](arg1);
+}
+
+export function ExportGeoJSON(arg1) {
+  return window['go']['main']['App']['ExportGeoJSON'](arg1);
 }
 
 export function ExportHeatmapData(arg1, arg2) {
   return window['go']['main']['App']['ExportHeatmapData'](arg1, arg2);
 }
 
+export function ExportResults(arg1, arg2) {
+  return window['go']['main']['App']['ExportResults'](arg1, arg2);
+}
+
 export function ExportThrowCoordinates() {
   return window['go']['main']['App']['ExportThrowCoordinates']();
 }
@@ -38,30 +94,154 @@ export function ExportThrowCoordinatesAsCSV() {
   return window['go']['main']['App']['ExportThrowCoordinatesAsCSV']();
 }
 
+export function ExportThrowCoordinatesAsCSVWithOptions(arg1) {
+  return window['go']['main']['App']['ExportThrowCoordinatesAsCSVWithOptions'](arg1);
+}
+
 export function ExportThrowCoordinatesForCircle(arg1) {
   return window['go']['main']['App']['ExportThrowCoordinatesForCircle'](arg1);
 }
 
+export function GetAPIServerStatus() {
+  return window['go']['main']['App']['GetAPIServerStatus']();
+}
+
+export function GetAthleteAnalytics(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetAthleteAnalytics'](arg1, arg2, arg3);
+}
+
+export function GetAthletesAnalytics(arg1) {
+  return window['go']['main']['App']['GetAthletesAnalytics'](arg1);
+}
+
+export function GetCSVExportColumns() {
+  return window['go']['main']['App']['GetCSVExportColumns']();
+}
+
 export function GetCalibration(arg1) {
   return window['go']['main']['App']['GetCalibration'](arg1);
 }
 
-export function GetCurrentSession() {
-  return window['go']['main']['App']['GetCurrentSession']();
+export function GetCompetitionImplements() {
+  return window['go']['main']['App']['GetCompetitionImplements']();
+}
+
+export function GetCurrentSession(arg1) {
+  return window['go']['main']['App']['GetCurrentSession'](arg1);
+}
+
+export function GetDeviceStatuses() {
+  return window['go']['main']['App']['GetDeviceStatuses']();
+}
+
+export function GetEventResult(arg1) {
+  return window['go']['main']['App']['GetEventResult'](arg1);
+}
+
+export function GetHeatmap(arg1) {
+  return window['go']['main']['App']['GetHeatmap'](arg1);
 }
 
 export function GetReliableEDMReading(arg1) {
   return window['go']['main']['App']['GetReliableEDMReading'](arg1);
 }
 
+export function GetScoreboardConfig(arg1) {
+  return window['go']['main']['App']['GetScoreboardConfig'](arg1);
+}
+
+export function GetScoreboardState(arg1) {
+  return window['go']['main']['App']['GetScoreboardState'](arg1);
+}
+
+export function GetScoreboardStatus(arg1) {
+  return window['go']['main']['App']['GetScoreboardStatus'](arg1);
+}
+
+export function GetScoreboardStatuses() {
+  return window['go']['main']['App']['GetScoreboardStatuses']();
+}
+
+export function GetSession(arg1) {
+  return window['go']['main']['App']['GetSession'](arg1);
+}
+
+export function GetStandings(arg1, arg2) {
+  return window['go']['main']['App']['GetStandings'](arg1, arg2);
+}
+
+export function GetSyncConfig() {
+  return window['go']['main']['App']['GetSyncConfig']();
+}
+
+export function GetSyncStatus() {
+  return window['go']['main']['App']['GetSyncStatus']();
+}
+
+export function GetSyncedResults() {
+  return window['go']['main']['App']['GetSyncedResults']();
+}
+
 export function GetThrowStatistics(arg1) {
   return window['go']['main']['App']['GetThrowStatistics'](arg1);
 }
 
+export function ImportCSVStartList(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ImportCSVStartList'](arg1, arg2, arg3);
+}
+
+export function ImportJSONStartList(arg1, arg2) {
+  return window['go']['main']['App']['ImportJSONStartList'](arg1, arg2);
+}
+
+export function ImportLynxStartList(arg1) {
+  return window['go']['main']['App']['ImportLynxStartList'](arg1);
+}
+
+export function ListPortBindings() {
+  return window['go']['main']['App']['ListPortBindings']();
+}
+
+export function ListResultEvents() {
+  return window['go']['main']['App']['ListResultEvents']();
+}
+
+export function ListScoreboardLayouts() {
+  return window['go']['main']['App']['ListScoreboardLayouts']();
+}
+
+export function ListScoreboardProtocols() {
+  return window['go']['main']['App']['ListScoreboardProtocols']();
+}
+
+export function ListSerialPortDetails() {
+  return window['go']['main']['App']['ListSerialPortDetails']();
+}
+
 export function ListSerialPorts() {
   return window['go']['main']['App']['ListSerialPorts']();
 }
 
+export function ListSerialPresets() {
+  return window['go']['main']['App']['ListSerialPresets']();
+}
+
+export function ListSessions() {
+  return window['go']['main']['App']['ListSessions']();
+}
+
+export function ListStartLists() {
+  return window['go']['main']['App']['ListStartLists']();
+}
+
+export function ListStations() {
+  return window['go']['main']['App']['ListStations']();
+}
+
+export function MeasureSectorCentreline(arg1) {
+  return window['go']['main']['App']['MeasureSectorCentreline'](arg1);
+}
+
 export function MeasureThrow(arg1) {
   return window['go']['main']['App']['MeasureThrow'](arg1);
 }
@@ -70,6 +250,38 @@ export function MeasureWind(arg1) {
   return window['go']['main']['App']['MeasureWind'](arg1);
 }
 
+export function MergeSessions(arg1, arg2) {
+  return window['go']['main']['App']['MergeSessions'](arg1, arg2);
+}
+
+export function NextTrainingAthlete(arg1) {
+  return window['go']['main']['App']['NextTrainingAthlete'](arg1);
+}
+
+export function PreviewCSVStartList(arg1) {
+  return window['go']['main']['App']['PreviewCSVStartList'](arg1);
+}
+
+export function PrintJudgesCard(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['PrintJudgesCard'](arg1, arg2, arg3, arg4);
+}
+
+export function PrintResultSheet(arg1, arg2) {
+  return window['go']['main']['App']['PrintResultSheet'](arg1, arg2);
+}
+
+export function RecordFoul(arg1) {
+  return window['go']['main']['App']['RecordFoul'](arg1);
+}
+
+export function RecordPass(arg1) {
+  return window['go']['main']['App']['RecordPass'](arg1);
+}
+
+export function RenameSession(arg1, arg2) {
+  return window['go']['main']['App']['RenameSession'](arg1, arg2);
+}
+
 export function ResetCalibration(arg1) {
   return window['go']['main']['App']['ResetCalibration'](arg1);
 }
@@ -78,26 +290,106 @@ export function SaveCalibration(arg1, arg2) {
   return window['go']['main']['App']['SaveCalibration'](arg1, arg2);
 }
 
+export function SaveScoreboardLayout(arg1) {
+  return window['go']['main']['App']['SaveScoreboardLayout'](arg1);
+}
+
+export function SaveSerialPreset(arg1) {
+  return window['go']['main']['App']['SaveSerialPreset'](arg1);
+}
+
 export function SendToScoreboard(arg1) {
   return window['go']['main']['App']['SendToScoreboard'](arg1);
 }
 
+export function SendToScoreboardLine(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SendToScoreboardLine'](arg1, arg2, arg3);
+}
+
 export function SetCircleCentre(arg1) {
   return window['go']['main']['App']['SetCircleCentre'](arg1);
 }
 
+export function SetCompetitionImplement(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetCompetitionImplement'](arg1, arg2, arg3);
+}
+
+export function SetCurrentAthlete(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetCurrentAthlete'](arg1, arg2, arg3, arg4);
+}
+
 export function SetDemoMode(arg1) {
   return window['go']['main']['App']['SetDemoMode'](arg1);
 }
 
-export function StartThrowSession(arg1, arg2) {
-  return window['go']['main']['App']['StartThrowSession'](arg1, arg2);
+export function SetScoreboardBrightness(arg1, arg2) {
+  return window['go']['main']['App']['SetScoreboardBrightness'](arg1, arg2);
+}
+
+export function SetScoreboardConfig(arg1, arg2) {
+  return window['go']['main']['App']['SetScoreboardConfig'](arg1, arg2);
+}
+
+export function SetScoreboardLayout(arg1, arg2) {
+  return window['go']['main']['App']['SetScoreboardLayout'](arg1, arg2);
+}
+
+export function SetStationEvent(arg1, arg2) {
+  return window['go']['main']['App']['SetStationEvent'](arg1, arg2);
+}
+
+export function SetStationScoreboard(arg1, arg2) {
+  return window['go']['main']['App']['SetStationScoreboard'](arg1, arg2);
+}
+
+export function SetSyncConfig(arg1) {
+  return window['go']['main']['App']['SetSyncConfig'](arg1);
+}
+
+export function SetTrainingImplement(arg1, arg2) {
+  return window['go']['main']['App']['SetTrainingImplement'](arg1, arg2);
+}
+
+export function SetTrainingRoster(arg1, arg2) {
+  return window['go']['main']['App']['SetTrainingRoster'](arg1, arg2);
+}
+
+export function SetTrainingTags(arg1, arg2) {
+  return window['go']['main']['App']['SetTrainingTags'](arg1, arg2);
+}
+
+export function StartAPIServer(arg1) {
+  return window['go']['main']['App']['StartAPIServer'](arg1);
+}
+
+export function StartThrowSession(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartThrowSession'](arg1, arg2, arg3);
+}
+
+export function StartTrainingSession(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['StartTrainingSession'](arg1, arg2, arg3, arg4, arg5);
 }
 
 export function StartWindListener(arg1, arg2) {
   return window['go']['main']['App']['StartWindListener'](arg1, arg2);
 }
 
+export function StopAPIServer() {
+  return window['go']['main']['App']['StopAPIServer']();
+}
+
+export function SyncNow() {
+  return window['go']['main']['App']['SyncNow']();
+}
+
+export function TagThrow(arg1, arg2) {
+  return window['go']['main']['App']['TagThrow'](arg1, arg2);
+}
+
+export function UnbindDevice(arg1) {
+  return window['go']['main']['App']['UnbindDevice'](arg1);
+}
+
 export function VerifyCircleEdge(arg1) {
   return window['go']['main']['App']['VerifyCircleEdge'](arg1);
 }
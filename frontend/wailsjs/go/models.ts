@@ -1,5 +1,235 @@
 export namespace main {
 	
+	export class APIServerConfig {
+	    enabled: boolean;
+	    listenAddress: string;
+	    token: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new APIServerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.listenAddress = source["listenAddress"];
+	        this.token = source["token"];
+	    }
+	}
+	export class APIServerStatus {
+	    running: boolean;
+	    listenAddress: string;
+	    token: string;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new APIServerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.listenAddress = source["listenAddress"];
+	        this.token = source["token"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class AnalyticsDay {
+	    date: string;
+	    throws: number;
+	    fouls: number;
+	    best: number;
+	    mean: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnalyticsDay(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.throws = source["throws"];
+	        this.fouls = source["fouls"];
+	        this.best = source["best"];
+	        this.mean = source["mean"];
+	    }
+	}
+	export class AnalyticsThrow {
+	    id?: string;
+	    // Go type: time
+	    timestamp: any;
+	    distance: number;
+	    lateral: number;
+	    movingAverage: number;
+	    personalBest: boolean;
+	    seasonBest: boolean;
+	    implement?: string;
+	    training?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AnalyticsThrow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.distance = source["distance"];
+	        this.lateral = source["lateral"];
+	        this.movingAverage = source["movingAverage"];
+	        this.personalBest = source["personalBest"];
+	        this.seasonBest = source["seasonBest"];
+	        this.implement = source["implement"];
+	        this.training = source["training"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AthleteAnalytics {
+	    athleteId: string;
+	    name: string;
+	    circleType: string;
+	    throws: number;
+	    fouls: number;
+	    foulRate: number;
+	    implement?: string;
+	    personalBest: number;
+	    seasonBest: number;
+	    mean: number;
+	    stdDev: number;
+	    lateralMean: number;
+	    lateralStdDev: number;
+	    trendPerWeek: number;
+	    window: number;
+	    history: AnalyticsThrow[];
+	    days: AnalyticsDay[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AthleteAnalytics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.athleteId = source["athleteId"];
+	        this.name = source["name"];
+	        this.circleType = source["circleType"];
+	        this.throws = source["throws"];
+	        this.fouls = source["fouls"];
+	        this.foulRate = source["foulRate"];
+	        this.implement = source["implement"];
+	        this.personalBest = source["personalBest"];
+	        this.seasonBest = source["seasonBest"];
+	        this.mean = source["mean"];
+	        this.stdDev = source["stdDev"];
+	        this.lateralMean = source["lateralMean"];
+	        this.lateralStdDev = source["lateralStdDev"];
+	        this.trendPerWeek = source["trendPerWeek"];
+	        this.window = source["window"];
+	        this.history = this.convertValues(source["history"], AnalyticsThrow);
+	        this.days = this.convertValues(source["days"], AnalyticsDay);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AttemptResult {
+	    attempt: number;
+	    kind: string;
+	    distance?: number;
+	    wind?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AttemptResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.attempt = source["attempt"];
+	        this.kind = source["kind"];
+	        this.distance = source["distance"];
+	        this.wind = source["wind"];
+	    }
+	}
+	export class AthleteResult {
+	    place: number;
+	    status?: string;
+	    bib: string;
+	    firstName: string;
+	    lastName: string;
+	    affiliation: string;
+	    order: number;
+	    best: number;
+	    bestWind?: number;
+	    attempts: AttemptResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AthleteResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.place = source["place"];
+	        this.status = source["status"];
+	        this.bib = source["bib"];
+	        this.firstName = source["firstName"];
+	        this.lastName = source["lastName"];
+	        this.affiliation = source["affiliation"];
+	        this.order = source["order"];
+	        this.best = source["best"];
+	        this.bestWind = source["bestWind"];
+	        this.attempts = this.convertValues(source["attempts"], AttemptResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 	export class AveragedEDMReading {
 	    slopeDistanceMm: number;
 	    vAzDecimal: number;
@@ -11,66 +241,833 @@ export namespace main {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.slopeDistanceMm = source["slopeDistanceMm"];
-	        this.vAzDecimal = source["vAzDecimal"];
-	        this.harDecimal = source["harDecimal"];
+	        this.slopeDistanceMm = source["slopeDistanceMm"];
+	        this.vAzDecimal = source["vAzDecimal"];
+	        this.harDecimal = source["harDecimal"];
+	    }
+	}
+	export class CSVColumnMapping {
+	    bib: number;
+	    firstName: number;
+	    lastName: number;
+	    name: number;
+	    affiliation: number;
+	    event: number;
+	    order: number;
+	    hasHeader: boolean;
+	    delimiter: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVColumnMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bib = source["bib"];
+	        this.firstName = source["firstName"];
+	        this.lastName = source["lastName"];
+	        this.name = source["name"];
+	        this.affiliation = source["affiliation"];
+	        this.event = source["event"];
+	        this.order = source["order"];
+	        this.hasHeader = source["hasHeader"];
+	        this.delimiter = source["delimiter"];
+	    }
+	}
+	export class CSVExportOptions {
+	    columns: string[];
+	    units: string;
+	    decimalSeparator: string;
+	    timeZone: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.columns = source["columns"];
+	        this.units = source["units"];
+	        this.decimalSeparator = source["decimalSeparator"];
+	        this.timeZone = source["timeZone"];
+	    }
+	}
+	export class CSVPreview {
+	    headers: string[];
+	    rows: string[][];
+	    mapping: CSVColumnMapping;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSVPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.headers = source["headers"];
+	        this.rows = source["rows"];
+	        this.mapping = this.convertValues(source["mapping"], CSVColumnMapping);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CompetitionImplements {
+	    byCircleType: Record<string, string>;
+	    byAthlete: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new CompetitionImplements(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.byCircleType = source["byCircleType"];
+	        this.byAthlete = source["byAthlete"];
+	    }
+	}
+	export class DeviceStatus {
+	    deviceType: string;
+	    connectionType: string;
+	    address: string;
+	    state: string;
+	    lastError?: string;
+	    // Go type: time
+	    lastActivity: any;
+	    reconnects: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceType = source["deviceType"];
+	        this.connectionType = source["connectionType"];
+	        this.address = source["address"];
+	        this.state = source["state"];
+	        this.lastError = source["lastError"];
+	        this.lastActivity = this.convertValues(source["lastActivity"], null);
+	        this.reconnects = source["reconnects"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SerialSettings {
+	    baudRate: number;
+	    dataBits: number;
+	    parity: string;
+	    stopBits: string;
+	    flowControl: string;
+	    dtr: boolean;
+	    rts: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SerialSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.baudRate = source["baudRate"];
+	        this.dataBits = source["dataBits"];
+	        this.parity = source["parity"];
+	        this.stopBits = source["stopBits"];
+	        this.flowControl = source["flowControl"];
+	        this.dtr = source["dtr"];
+	        this.rts = source["rts"];
+	    }
+	}
+	export class DiscoveredDevice {
+	    deviceType: string;
+	    model: string;
+	    connectionType: string;
+	    address: string;
+	    settings?: SerialSettings;
+	    presetName?: string;
+	    detail: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscoveredDevice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceType = source["deviceType"];
+	        this.model = source["model"];
+	        this.connectionType = source["connectionType"];
+	        this.address = source["address"];
+	        this.settings = this.convertValues(source["settings"], SerialSettings);
+	        this.presetName = source["presetName"];
+	        this.detail = source["detail"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DiscoveryOptions {
+	    includeSerial: boolean;
+	    subnet: string;
+	    ports: number[];
+	    timeoutMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiscoveryOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.includeSerial = source["includeSerial"];
+	        this.subnet = source["subnet"];
+	        this.ports = source["ports"];
+	        this.timeoutMs = source["timeoutMs"];
+	    }
+	}
+	export class EdgeVerificationResult {
+	    measuredRadius: number;
+	    differenceMm: number;
+	    isInTolerance: boolean;
+	    toleranceAppliedMm: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EdgeVerificationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.measuredRadius = source["measuredRadius"];
+	        this.differenceMm = source["differenceMm"];
+	        this.isInTolerance = source["isInTolerance"];
+	        this.toleranceAppliedMm = source["toleranceAppliedMm"];
+	    }
+	}
+	export class EDMPoint {
+	    x: number;
+	    y: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new EDMPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	    }
+	}
+	export class EDMCalibrationData {
+	    deviceId: string;
+	    // Go type: time
+	    timestamp: any;
+	    selectedCircleType: string;
+	    targetRadius: number;
+	    stationCoordinates: EDMPoint;
+	    isCentreSet: boolean;
+	    edgeVerificationResult?: EdgeVerificationResult;
+	    sectorBearing: number;
+	    isSectorSet: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new EDMCalibrationData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceId = source["deviceId"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.selectedCircleType = source["selectedCircleType"];
+	        this.targetRadius = source["targetRadius"];
+	        this.stationCoordinates = this.convertValues(source["stationCoordinates"], EDMPoint);
+	        this.isCentreSet = source["isCentreSet"];
+	        this.edgeVerificationResult = this.convertValues(source["edgeVerificationResult"], EdgeVerificationResult);
+	        this.sectorBearing = source["sectorBearing"];
+	        this.isSectorSet = source["isSectorSet"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class EventResult {
+	    event: string;
+	    circleType: string;
+	    eventNumber?: string;
+	    round?: string;
+	    flight?: string;
+	    rounds: number;
+	    hasWind: boolean;
+	    athletes: AthleteResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new EventResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.event = source["event"];
+	        this.circleType = source["circleType"];
+	        this.eventNumber = source["eventNumber"];
+	        this.round = source["round"];
+	        this.flight = source["flight"];
+	        this.rounds = source["rounds"];
+	        this.hasWind = source["hasWind"];
+	        this.athletes = this.convertValues(source["athletes"], AthleteResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ThrowCoordinate {
+	    x: number;
+	    y: number;
+	    distance: number;
+	    circleType: string;
+	    // Go type: time
+	    timestamp: any;
+	    id?: string;
+	    origin?: string;
+	    athleteId: string;
+	    station?: string;
+	    event?: string;
+	    attempt?: number;
+	    sessionId?: string;
+	    competitionRound: string;
+	    training?: boolean;
+	    implement?: string;
+	    tags?: string[];
+	    edmReading: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ThrowCoordinate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.distance = source["distance"];
+	        this.circleType = source["circleType"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.id = source["id"];
+	        this.origin = source["origin"];
+	        this.athleteId = source["athleteId"];
+	        this.station = source["station"];
+	        this.event = source["event"];
+	        this.attempt = source["attempt"];
+	        this.sessionId = source["sessionId"];
+	        this.competitionRound = source["competitionRound"];
+	        this.training = source["training"];
+	        this.implement = source["implement"];
+	        this.tags = source["tags"];
+	        this.edmReading = source["edmReading"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HeatmapPoint {
+	    id?: string;
+	    athleteId: string;
+	    attempt?: number;
+	    x: number;
+	    y: number;
+	    distance: number;
+	    angle: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.athleteId = source["athleteId"];
+	        this.attempt = source["attempt"];
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.distance = source["distance"];
+	        this.angle = source["angle"];
+	    }
+	}
+	export class HeatmapBounds {
+	    minX: number;
+	    maxX: number;
+	    minY: number;
+	    maxY: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapBounds(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.minX = source["minX"];
+	        this.maxX = source["maxX"];
+	        this.minY = source["minY"];
+	        this.maxY = source["maxY"];
+	    }
+	}
+	export class Heatmap {
+	    circleType: string;
+	    mode: string;
+	    frame: string;
+	    gridSize: number;
+	    angleStep?: number;
+	    bandwidth?: number;
+	    bounds: HeatmapBounds;
+	    gridWidth: number;
+	    gridHeight: number;
+	    heatmap: number[][];
+	    maxValue: number;
+	    totalThrows: number;
+	    outside: number;
+	    points: HeatmapPoint[];
+	    coordinates: ThrowCoordinate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Heatmap(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.circleType = source["circleType"];
+	        this.mode = source["mode"];
+	        this.frame = source["frame"];
+	        this.gridSize = source["gridSize"];
+	        this.angleStep = source["angleStep"];
+	        this.bandwidth = source["bandwidth"];
+	        this.bounds = this.convertValues(source["bounds"], HeatmapBounds);
+	        this.gridWidth = source["gridWidth"];
+	        this.gridHeight = source["gridHeight"];
+	        this.heatmap = source["heatmap"];
+	        this.maxValue = source["maxValue"];
+	        this.totalThrows = source["totalThrows"];
+	        this.outside = source["outside"];
+	        this.points = this.convertValues(source["points"], HeatmapPoint);
+	        this.coordinates = this.convertValues(source["coordinates"], ThrowCoordinate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class HeatmapRequest {
+	    circleType: string;
+	    station: string;
+	    athleteId: string;
+	    event: string;
+	    sessionId: string;
+	    // Go type: time
+	    from?: any;
+	    // Go type: time
+	    to?: any;
+	    mode: string;
+	    frame: string;
+	    cellSize: number;
+	    angleStep: number;
+	    bandwidth: number;
+	    fitToData: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.circleType = source["circleType"];
+	        this.station = source["station"];
+	        this.athleteId = source["athleteId"];
+	        this.event = source["event"];
+	        this.sessionId = source["sessionId"];
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	        this.mode = source["mode"];
+	        this.frame = source["frame"];
+	        this.cellSize = source["cellSize"];
+	        this.angleStep = source["angleStep"];
+	        this.bandwidth = source["bandwidth"];
+	        this.fitToData = source["fitToData"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PortBinding {
+	    deviceType: string;
+	    serialNumber: string;
+	    vid: string;
+	    pid: string;
+	    settings: SerialSettings;
+	    lastPortName: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortBinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceType = source["deviceType"];
+	        this.serialNumber = source["serialNumber"];
+	        this.vid = source["vid"];
+	        this.pid = source["pid"];
+	        this.settings = this.convertValues(source["settings"], SerialSettings);
+	        this.lastPortName = source["lastPortName"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ScoreboardConfig {
+	    protocol: string;
+	    address: number;
+	    lineWidth: number;
+	    brightness: number;
+	    layout: string;
+	    expectAck: boolean;
+	    ackTimeoutMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreboardConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.protocol = source["protocol"];
+	        this.address = source["address"];
+	        this.lineWidth = source["lineWidth"];
+	        this.brightness = source["brightness"];
+	        this.layout = source["layout"];
+	        this.expectAck = source["expectAck"];
+	        this.ackTimeoutMs = source["ackTimeoutMs"];
+	    }
+	}
+	export class ScoreboardPage {
+	    lines: string[];
+	    durationMs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreboardPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lines = source["lines"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class ScoreboardLayout {
+	    name: string;
+	    pages: ScoreboardPage[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreboardLayout(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.pages = this.convertValues(source["pages"], ScoreboardPage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class ScoreboardProtocolInfo {
+	    name: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreboardProtocolInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	    }
+	}
+	export class ScoreboardState {
+	    bib: string;
+	    name: string;
+	    attempt: number;
+	    mark: string;
+	    position: number;
+	    wind: string;
+	    latest: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScoreboardState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bib = source["bib"];
+	        this.name = source["name"];
+	        this.attempt = source["attempt"];
+	        this.mark = source["mark"];
+	        this.position = source["position"];
+	        this.wind = source["wind"];
+	        this.latest = source["latest"];
 	    }
 	}
-	export class EdgeVerificationResult {
-	    measuredRadius: number;
-	    differenceMm: number;
-	    isInTolerance: boolean;
-	    toleranceAppliedMm: number;
+	export class ScoreboardStatus {
+	    device: string;
+	    connected: boolean;
+	    protocol: string;
+	    address: string;
+	    queueLength: number;
+	    held: number;
+	    lastSent: string;
+	    // Go type: time
+	    lastSentAt: any;
+	    lastAcknowledged: boolean;
+	    lastError?: string;
+	    // Go type: time
+	    lastErrorAt: any;
+	    consecutiveFailures: number;
+	    delivered: number;
+	    failed: number;
+	    dropped: number;
+	    reconnects: number;
+	    state: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new EdgeVerificationResult(source);
+	        return new ScoreboardStatus(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.measuredRadius = source["measuredRadius"];
-	        this.differenceMm = source["differenceMm"];
-	        this.isInTolerance = source["isInTolerance"];
-	        this.toleranceAppliedMm = source["toleranceAppliedMm"];
+	        this.device = source["device"];
+	        this.connected = source["connected"];
+	        this.protocol = source["protocol"];
+	        this.address = source["address"];
+	        this.queueLength = source["queueLength"];
+	        this.held = source["held"];
+	        this.lastSent = source["lastSent"];
+	        this.lastSentAt = this.convertValues(source["lastSentAt"], null);
+	        this.lastAcknowledged = source["lastAcknowledged"];
+	        this.lastError = source["lastError"];
+	        this.lastErrorAt = this.convertValues(source["lastErrorAt"], null);
+	        this.consecutiveFailures = source["consecutiveFailures"];
+	        this.delivered = source["delivered"];
+	        this.failed = source["failed"];
+	        this.dropped = source["dropped"];
+	        this.reconnects = source["reconnects"];
+	        this.state = source["state"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class EDMPoint {
-	    x: number;
-	    y: number;
+	export class SerialPortInfo {
+	    name: string;
+	    isUsb: boolean;
+	    vid: string;
+	    pid: string;
+	    serialNumber: string;
+	    product: string;
+	    boundTo?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new EDMPoint(source);
+	        return new SerialPortInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.x = source["x"];
-	        this.y = source["y"];
+	        this.name = source["name"];
+	        this.isUsb = source["isUsb"];
+	        this.vid = source["vid"];
+	        this.pid = source["pid"];
+	        this.serialNumber = source["serialNumber"];
+	        this.product = source["product"];
+	        this.boundTo = source["boundTo"];
 	    }
 	}
-	export class EDMCalibrationData {
-	    deviceId: string;
-	    // Go type: time
-	    timestamp: any;
-	    selectedCircleType: string;
-	    targetRadius: number;
-	    stationCoordinates: EDMPoint;
-	    isCentreSet: boolean;
-	    edgeVerificationResult?: EdgeVerificationResult;
+	export class SerialPreset {
+	    name: string;
+	    model: string;
+	    deviceType: string;
+	    settings: SerialSettings;
+	    builtIn: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new EDMCalibrationData(source);
+	        return new SerialPreset(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.deviceId = source["deviceId"];
-	        this.timestamp = this.convertValues(source["timestamp"], null);
-	        this.selectedCircleType = source["selectedCircleType"];
-	        this.targetRadius = source["targetRadius"];
-	        this.stationCoordinates = this.convertValues(source["stationCoordinates"], EDMPoint);
-	        this.isCentreSet = source["isCentreSet"];
-	        this.edgeVerificationResult = this.convertValues(source["edgeVerificationResult"], EdgeVerificationResult);
+	        this.name = source["name"];
+	        this.model = source["model"];
+	        this.deviceType = source["deviceType"];
+	        this.settings = this.convertValues(source["settings"], SerialSettings);
+	        this.builtIn = source["builtIn"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -92,7 +1089,6 @@ export namespace main {
 		}
 	}
 	
-	
 	export class SessionStatistics {
 	    totalThrows: number;
 	    averageX: number;
@@ -101,6 +1097,8 @@ export namespace main {
 	    minDistance: number;
 	    averageDistance: number;
 	    spreadRadius: number;
+	    byAthlete?: Record<string, SessionStatistics>;
+	    byImplement?: Record<string, SessionStatistics>;
 	
 	    static createFrom(source: any = {}) {
 	        return new SessionStatistics(source);
@@ -115,33 +1113,271 @@ export namespace main {
 	        this.minDistance = source["minDistance"];
 	        this.averageDistance = source["averageDistance"];
 	        this.spreadRadius = source["spreadRadius"];
+	        this.byAthlete = this.convertValues(source["byAthlete"], SessionStatistics, true);
+	        this.byImplement = this.convertValues(source["byImplement"], SessionStatistics, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Standing {
+	    position: number;
+	    athleteId: string;
+	    best: number;
+	    marks: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Standing(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.position = source["position"];
+	        this.athleteId = source["athleteId"];
+	        this.best = source["best"];
+	        this.marks = source["marks"];
 	    }
 	}
-	export class ThrowCoordinate {
-	    x: number;
-	    y: number;
-	    distance: number;
+	export class StartListEntry {
+	    bib: string;
+	    firstName: string;
+	    lastName: string;
+	    affiliation: string;
+	    order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StartListEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bib = source["bib"];
+	        this.firstName = source["firstName"];
+	        this.lastName = source["lastName"];
+	        this.affiliation = source["affiliation"];
+	        this.order = source["order"];
+	    }
+	}
+	export class StartList {
+	    event: string;
+	    source: string;
+	    eventNumber?: string;
+	    round?: string;
+	    flight?: string;
+	    // Go type: time
+	    importedAt: any;
+	    entries: StartListEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new StartList(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.event = source["event"];
+	        this.source = source["source"];
+	        this.eventNumber = source["eventNumber"];
+	        this.round = source["round"];
+	        this.flight = source["flight"];
+	        this.importedAt = this.convertValues(source["importedAt"], null);
+	        this.entries = this.convertValues(source["entries"], StartListEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class StationInfo {
+	    deviceType: string;
+	    connected: boolean;
 	    circleType: string;
+	    isCentreSet: boolean;
+	    scoreboard: string;
+	    event?: string;
+	    sessionId?: string;
+	    board: ScoreboardState;
+	
+	    static createFrom(source: any = {}) {
+	        return new StationInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deviceType = source["deviceType"];
+	        this.connected = source["connected"];
+	        this.circleType = source["circleType"];
+	        this.isCentreSet = source["isCentreSet"];
+	        this.scoreboard = source["scoreboard"];
+	        this.event = source["event"];
+	        this.sessionId = source["sessionId"];
+	        this.board = this.convertValues(source["board"], ScoreboardState);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncConfig {
+	    enabled: boolean;
+	    role: string;
+	    instanceName: string;
+	    centralUrl: string;
+	    token: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.role = source["role"];
+	        this.instanceName = source["instanceName"];
+	        this.centralUrl = source["centralUrl"];
+	        this.token = source["token"];
+	    }
+	}
+	export class SyncPeer {
+	    origin: string;
+	    // Go type: time
+	    lastSeen: any;
+	    marks: number;
+	    attempts: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncPeer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.origin = source["origin"];
+	        this.lastSeen = this.convertValues(source["lastSeen"], null);
+	        this.marks = source["marks"];
+	        this.attempts = source["attempts"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncStatus {
+	    enabled: boolean;
+	    role: string;
+	    origin: string;
+	    pending: number;
+	    // Go type: time
+	    lastSentAt: any;
+	    lastError?: string;
+	    peers?: SyncPeer[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.role = source["role"];
+	        this.origin = source["origin"];
+	        this.pending = source["pending"];
+	        this.lastSentAt = this.convertValues(source["lastSentAt"], null);
+	        this.lastError = source["lastError"];
+	        this.peers = this.convertValues(source["peers"], SyncPeer);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class WindSampleEvent {
+	    deviceType: string;
+	    value: number;
 	    // Go type: time
 	    timestamp: any;
-	    athleteId: string;
-	    competitionRound: string;
-	    edmReading: string;
+	    origin?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ThrowCoordinate(source);
+	        return new WindSampleEvent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.x = source["x"];
-	        this.y = source["y"];
-	        this.distance = source["distance"];
-	        this.circleType = source["circleType"];
+	        this.deviceType = source["deviceType"];
+	        this.value = source["value"];
 	        this.timestamp = this.convertValues(source["timestamp"], null);
-	        this.athleteId = source["athleteId"];
-	        this.competitionRound = source["competitionRound"];
-	        this.edmReading = source["edmReading"];
+	        this.origin = source["origin"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -164,7 +1400,13 @@ export namespace main {
 	}
 	export class ThrowSession {
 	    sessionId: string;
+	    name?: string;
+	    station?: string;
+	    origin?: string;
 	    circleType: string;
+	    event?: string;
+	    mode?: string;
+	    roster?: string[];
 	    // Go type: time
 	    startTime: any;
 	    // Go type: time
@@ -179,7 +1421,13 @@ export namespace main {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.sessionId = source["sessionId"];
+	        this.name = source["name"];
+	        this.station = source["station"];
+	        this.origin = source["origin"];
 	        this.circleType = source["circleType"];
+	        this.event = source["event"];
+	        this.mode = source["mode"];
+	        this.roster = source["roster"];
 	        this.startTime = this.convertValues(source["startTime"], null);
 	        this.endTime = this.convertValues(source["endTime"], null);
 	        this.coordinates = this.convertValues(source["coordinates"], ThrowCoordinate);
@@ -204,6 +1452,42 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class SyncedResults {
+	    sessions: ThrowSession[];
+	    calibrations: Record<string, EDMCalibrationData>;
+	    wind: WindSampleEvent[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncedResults(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessions = this.convertValues(source["sessions"], ThrowSession);
+	        this.calibrations = this.convertValues(source["calibrations"], EDMCalibrationData, true);
+	        this.wind = this.convertValues(source["wind"], WindSampleEvent);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
 
 }
 
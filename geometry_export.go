@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+)
+
+// Landing positions and circle geometry for CAD/GIS tools. Each station
+// measures in its own frame: metres from its circle centre, with X along the
+// EDM's zero bearing. Exports are therefore per station, covering the throws
+// it measured for its current circle type.
+
+// sectorAngles are the throwing sector widths in degrees. Javelin sector lines
+// meet at the centre of the 8m arc.
+var sectorAngles = map[string]float64{
+	"SHOT":        34.92,
+	"DISCUS":      34.92,
+	"HAMMER":      34.92,
+	"JAVELIN_ARC": 28.96,
+}
+
+// Minimum sector line length, so the sector is visible before any throws
+const minSectorLength = 25.0
+
+// fieldGeometry is one station's frame and the throws measured in it
+type fieldGeometry struct {
+	Station     string
+	CircleType  string
+	Radius      float64
+	StationPos  EDMPoint
+	Centreline  float64 // Sector centreline bearing, radians from +X
+	SectorAngle float64 // Full sector width, radians
+	SectorLen   float64 // Length of the drawn sector lines
+	Throws      []ThrowCoordinate
+}
+
+// sectorCentreline is the calibrated sector direction in radians from +X,
+// or the EDM's zero bearing if it was never measured. It does not depend on
+// the throws, so the frame stays put as more are recorded.
+func sectorCentreline(cal *EDMCalibrationData) float64 {
+	if cal == nil || !cal.IsSectorSet {
+		return 0
+	}
+	return cal.SectorBearing * math.Pi / 180
+}
+
+// fieldGeometryLocked collects the station's calibration and throws. Caller
+// holds stateMux.
+func (a *App) fieldGeometryLocked(devType string) (*fieldGeometry, error) {
+	cal, ok := a.CalibrationStore[devType]
+	if !ok || !cal.IsCentreSet {
+		return nil, fmt.Errorf("%s has no circle centre set", devType)
+	}
+	g := &fieldGeometry{
+		Station:     devType,
+		CircleType:  cal.SelectedCircleType,
+		Radius:      cal.TargetRadius,
+		StationPos:  cal.StationCoordinates,
+		SectorAngle: sectorAngles[cal.SelectedCircleType] * math.Pi / 180,
+		SectorLen:   minSectorLength,
+	}
+	for _, coord := range a.throwCoordinates {
+		if coord.Station != devType || coord.CircleType != g.CircleType {
+			continue
+		}
+		g.Throws = append(g.Throws, coord)
+		g.SectorLen = max(g.SectorLen, math.Hypot(coord.X, coord.Y)*1.1)
+	}
+	g.Centreline = sectorCentreline(cal)
+	return g, nil
+}
+
+// sectorEnds are the far ends of the two sector lines, left then right
+func (g *fieldGeometry) sectorEnds() [2]EDMPoint {
+	var ends [2]EDMPoint
+	for i, side := range []float64{1, -1} {
+		angle := g.Centreline + side*g.SectorAngle/2
+		ends[i] = EDMPoint{X: g.SectorLen * math.Cos(angle), Y: g.SectorLen * math.Sin(angle)}
+	}
+	return ends
+}
+
+// circlePoints approximates the circle, or for the javelin the arc between
+// the sector lines
+func (g *fieldGeometry) circlePoints() [][2]float64 {
+	const segments = 64
+	start, sweep := 0.0, 2*math.Pi
+	if g.CircleType == "JAVELIN_ARC" {
+		start, sweep = g.Centreline-g.SectorAngle/2, g.SectorAngle
+	}
+	points := make([][2]float64, 0, segments+1)
+	for i := 0; i <= segments; i++ {
+		angle := start + sweep*float64(i)/segments
+		points = append(points, [2]float64{g.Radius * math.Cos(angle), g.Radius * math.Sin(angle)})
+	}
+	return points
+}
+
+// throwLabel is the text placed beside a landing in DXF
+func throwLabel(t ThrowCoordinate) string {
+	label := formatMark(truncateMark(t.Distance))
+	if t.Attempt > 0 {
+		label = fmt.Sprintf("#%d %s", t.Attempt, label)
+	}
+	if t.AthleteID != "" {
+		label = t.AthleteID + " " + label
+	}
+	return label
+}
+
+// --- GeoJSON ---
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Name     string           `json:"name"`
+	Frame    string           `json:"frame"` // Not WGS84, see the comment at the top of this file
+	Features []geoJSONFeature `json:"features"`
+}
+
+func geoJSONPoint(x, y float64, props map[string]interface{}) geoJSONFeature {
+	return geoJSONFeature{Type: "Feature", Geometry: geoJSONGeometry{Type: "Point", Coordinates: [2]float64{x, y}}, Properties: props}
+}
+
+func encodeGeoJSON(g *fieldGeometry) (string, error) {
+	fc := geoJSONCollection{
+		Type:  "FeatureCollection",
+		Name:  fmt.Sprintf("%s %s", g.Station, g.CircleType),
+		Frame: "local: metres from the circle centre, X along the EDM zero bearing",
+	}
+	fc.Features = append(fc.Features,
+		geoJSONPoint(0, 0, map[string]interface{}{"kind": "centre", "circleType": g.CircleType}),
+		geoJSONPoint(g.StationPos.X, g.StationPos.Y, map[string]interface{}{"kind": "station", "station": g.Station}),
+		geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONGeometry{Type: "LineString", Coordinates: g.circlePoints()},
+			Properties: map[string]interface{}{"kind": "circle", "radius": g.Radius},
+		},
+	)
+	for i, end := range g.sectorEnds() {
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONGeometry{Type: "LineString", Coordinates: [][2]float64{{0, 0}, {end.X, end.Y}}},
+			Properties: map[string]interface{}{"kind": "sector", "side": []string{"left", "right"}[i]},
+		})
+	}
+	for _, t := range g.Throws {
+		fc.Features = append(fc.Features, geoJSONPoint(t.X, t.Y, map[string]interface{}{
+			"kind":      "landing",
+			"id":        t.ID,
+			"athleteId": t.AthleteID,
+			"attempt":   t.Attempt,
+			"distance":  truncateMark(t.Distance),
+			"event":     t.Event,
+			"timestamp": t.Timestamp,
+		}))
+	}
+	data, err := json.MarshalIndent(fc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not encode GeoJSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// --- DXF ---
+
+// dxfWriter writes ASCII DXF (R12) group code/value pairs
+type dxfWriter struct {
+	b strings.Builder
+}
+
+func (w *dxfWriter) pair(code int, value interface{}) {
+	switch v := value.(type) {
+	case float64:
+		fmt.Fprintf(&w.b, "%d\n%.4f\n", code, v)
+	default:
+		fmt.Fprintf(&w.b, "%d\n%v\n", code, v)
+	}
+}
+
+func (w *dxfWriter) point(layer string, x, y float64) {
+	w.pair(0, "POINT")
+	w.pair(8, layer)
+	w.pair(10, x)
+	w.pair(20, y)
+	w.pair(30, 0.0)
+}
+
+func (w *dxfWriter) line(layer string, x1, y1, x2, y2 float64) {
+	w.pair(0, "LINE")
+	w.pair(8, layer)
+	w.pair(10, x1)
+	w.pair(20, y1)
+	w.pair(30, 0.0)
+	w.pair(11, x2)
+	w.pair(21, y2)
+	w.pair(31, 0.0)
+}
+
+func (w *dxfWriter) text(layer string, x, y, height float64, s string) {
+	w.pair(0, "TEXT")
+	w.pair(8, layer)
+	w.pair(10, x)
+	w.pair(20, y)
+	w.pair(30, 0.0)
+	w.pair(40, height)
+	w.pair(1, s)
+}
+
+// DXF layers, each with an AutoCAD colour index
+var dxfLayers = []struct {
+	name  string
+	color int
+}{{"CIRCLE", 7}, {"SECTOR", 1}, {"STATION", 5}, {"THROWS", 3}, {"LABELS", 8}}
+
+// encodeDXF draws the circle, sector lines and station, and each landing as
+// a point with a text label, since DXF points carry no attributes
+func encodeDXF(g *fieldGeometry) string {
+	var w dxfWriter
+	w.pair(0, "SECTION")
+	w.pair(2, "TABLES")
+	w.pair(0, "TABLE")
+	w.pair(2, "LAYER")
+	w.pair(70, len(dxfLayers))
+	for _, layer := range dxfLayers {
+		w.pair(0, "LAYER")
+		w.pair(2, layer.name)
+		w.pair(70, 0)
+		w.pair(62, layer.color)
+		w.pair(6, "CONTINUOUS")
+	}
+	w.pair(0, "ENDTAB")
+	w.pair(0, "ENDSEC")
+
+	w.pair(0, "SECTION")
+	w.pair(2, "ENTITIES")
+	if g.CircleType == "JAVELIN_ARC" {
+		start := (g.Centreline - g.SectorAngle/2) * 180 / math.Pi
+		w.pair(0, "ARC")
+		w.pair(8, "CIRCLE")
+		w.pair(10, 0.0)
+		w.pair(20, 0.0)
+		w.pair(30, 0.0)
+		w.pair(40, g.Radius)
+		w.pair(50, start)
+		w.pair(51, start+g.SectorAngle*180/math.Pi)
+	} else {
+		w.pair(0, "CIRCLE")
+		w.pair(8, "CIRCLE")
+		w.pair(10, 0.0)
+		w.pair(20, 0.0)
+		w.pair(30, 0.0)
+		w.pair(40, g.Radius)
+	}
+	w.point("CIRCLE", 0, 0)
+	for _, end := range g.sectorEnds() {
+		w.line("SECTOR", 0, 0, end.X, end.Y)
+	}
+	w.point("STATION", g.StationPos.X, g.StationPos.Y)
+	w.text("STATION", g.StationPos.X+0.3, g.StationPos.Y+0.3, 0.5, g.Station)
+	for _, t := range g.Throws {
+		w.point("THROWS", t.X, t.Y)
+		w.text("LABELS", t.X+0.2, t.Y+0.2, 0.3, throwLabel(t))
+	}
+	w.pair(0, "ENDSEC")
+	w.pair(0, "EOF")
+	return w.b.String()
+}
+
+// --- Wails Bindable Geometry Export Functions ---
+
+// ExportGeoJSON returns the station's circle, sector lines, position and
+// landings as a GeoJSON FeatureCollection in its local frame (metres)
+func (a *App) ExportGeoJSON(devType string) (string, error) {
+	a.stateMux.Lock()
+	g, err := a.fieldGeometryLocked(devType)
+	a.stateMux.Unlock()
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Exported GeoJSON for %s: %d landings", devType, len(g.Throws))
+	return encodeGeoJSON(g)
+}
+
+// ExportDXF returns the same geometry as an ASCII DXF drawing in metres
+func (a *App) ExportDXF(devType string) (string, error) {
+	a.stateMux.Lock()
+	g, err := a.fieldGeometryLocked(devType)
+	a.stateMux.Unlock()
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Exported DXF for %s: %d landings", devType, len(g.Throws))
+	return encodeDXF(g), nil
+}
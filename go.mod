@@ -5,6 +5,7 @@ go 1.23
 require (
 	github.com/wailsapp/wails/v2 v2.10.1
 	go.bug.st/serial v1.6.4
+	golang.org/x/net v0.35.0
 )
 
 require (
@@ -32,7 +33,6 @@ require (
 	github.com/wailsapp/go-webview2 v1.0.19 // indirect
 	github.com/wailsapp/mimetype v1.4.1 // indirect
 	golang.org/x/crypto v0.33.0 // indirect
-	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
 )
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+)
+
+// Heatmap modes and frames for HeatmapRequest
+const (
+	HeatmapGrid  = "grid"  // Counts per square cell
+	HeatmapKDE   = "kde"   // Gaussian kernel density per m²
+	HeatmapPolar = "polar" // Counts by distance × angle from the sector centreline
+
+	FrameRaw    = "raw"    // The station's own X/Y, as measured
+	FrameSector = "sector" // X along the calibrated sector centreline, Y to the left of it
+)
+
+// heatmapRanges are the fixed extents used for stable bounds, a little past
+// a world-class throw, so maps from different sessions line up
+var heatmapRanges = map[string]float64{
+	"SHOT":        25,
+	"DISCUS":      75,
+	"HAMMER":      85,
+	"JAVELIN_ARC": 100,
+}
+
+const (
+	defaultHeatmapRange = 100.0
+	polarAngleMargin    = 5.0    // Degrees shown outside each sector line
+	maxHeatmapCells     = 250000 // Guards against a tiny cell size
+)
+
+// HeatmapRequest selects the throws and how to bin them. Zero values give a
+// 1m grid in the sector frame with stable bounds.
+type HeatmapRequest struct {
+	CircleType string `json:"circleType"`
+	// Filters; empty matches everything
+	Station   string     `json:"station"`
+	AthleteID string     `json:"athleteId"`
+	Event     string     `json:"event"`
+	SessionID string     `json:"sessionId"`
+	From      *time.Time `json:"from,omitempty"`
+	To        *time.Time `json:"to,omitempty"`
+	// Binning
+	Mode      string  `json:"mode"`      // grid, kde or polar
+	Frame     string  `json:"frame"`     // sector or raw (grid and kde)
+	CellSize  float64 `json:"cellSize"`  // Metres; distance step for polar. Default 1
+	AngleStep float64 `json:"angleStep"` // Degrees, polar only. Default 2
+	Bandwidth float64 `json:"bandwidth"` // Kernel width in metres, kde only. Default 2 cells
+	FitToData bool    `json:"fitToData"` // Bounds from the throws instead of the circle type's fixed range
+}
+
+// HeatmapBounds is the X/Y extent, or for polar the distance (X) and angle
+// in degrees (Y) extent
+type HeatmapBounds struct {
+	MinX float64 `json:"minX"`
+	MaxX float64 `json:"maxX"`
+	MinY float64 `json:"minY"`
+	MaxY float64 `json:"maxY"`
+}
+
+// HeatmapPoint is a throw in the heatmap's frame
+type HeatmapPoint struct {
+	ID        string  `json:"id,omitempty"`
+	AthleteID string  `json:"athleteId"`
+	Attempt   int     `json:"attempt,omitempty"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Distance  float64 `json:"distance"`
+	Angle     float64 `json:"angle"` // Degrees left of the sector centreline
+}
+
+// Heatmap is a binned view of throws. Heatmap[row][column] runs from MinY
+// and MinX; cell (c, r) covers MinX+c*GridSize to MinX+(c+1)*GridSize.
+type Heatmap struct {
+	CircleType  string            `json:"circleType"`
+	Mode        string            `json:"mode"`
+	Frame       string            `json:"frame"`
+	GridSize    float64           `json:"gridSize"`            // Cell size, or distance step for polar
+	AngleStep   float64           `json:"angleStep,omitempty"` // Polar only
+	Bandwidth   float64           `json:"bandwidth,omitempty"` // KDE only
+	Bounds      HeatmapBounds     `json:"bounds"`
+	GridWidth   int               `json:"gridWidth"`
+	GridHeight  int               `json:"gridHeight"`
+	Heatmap     [][]float64       `json:"heatmap"`
+	MaxValue    float64           `json:"maxValue"`
+	TotalThrows int               `json:"totalThrows"`
+	Outside     int               `json:"outside"` // Throws beyond the bounds, not binned
+	Points      []HeatmapPoint    `json:"points"`
+	Coordinates []ThrowCoordinate `json:"coordinates"` // Raw throws, for overlays
+}
+
+// throwCentrelineLocked returns the sector centreline of the station that
+// measured a throw, from that instance's calibration for synced throws.
+// Caller holds stateMux.
+func (a *App) throwCentrelineLocked() func(coord ThrowCoordinate) float64 {
+	local := a.sync.origin()
+	return func(coord ThrowCoordinate) float64 {
+		if coord.Origin == "" || coord.Origin == local {
+			return sectorCentreline(a.CalibrationStore[coord.Station])
+		}
+		if cal, ok := a.synced.calibrations[coord.Origin+"/"+coord.Station]; ok {
+			return sectorCentreline(&cal)
+		}
+		return 0
+	}
+}
+
+// sessionThrowIDsLocked returns the IDs of a session's throws, looking in
+// current station sessions, the archive and sessions synced from other
+// instances. Caller holds stateMux.
+func (a *App) sessionThrowIDsLocked(sessionID string) (map[string]bool, error) {
+	sessions := make([]*ThrowSession, 0)
+	for _, st := range a.stations {
+		if st.session != nil {
+			sessions = append(sessions, st.session)
+		}
+	}
+	for _, session := range a.sessions {
+		sessions = append(sessions, session)
+	}
+	for _, session := range a.synced.sessions {
+		sessions = append(sessions, session)
+	}
+	ids := make(map[string]bool)
+	found := false
+	for _, session := range sessions {
+		if session.SessionID != sessionID {
+			continue
+		}
+		found = true
+		for _, coord := range session.Coordinates {
+			ids[coord.ID] = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("session '%s' not found", sessionID)
+	}
+	return ids, nil
+}
+
+// filterThrowsLocked applies the request's filters. Caller holds stateMux.
+func (a *App) filterThrowsLocked(req HeatmapRequest) ([]ThrowCoordinate, error) {
+	var sessionIDs map[string]bool
+	if req.SessionID != "" {
+		ids, err := a.sessionThrowIDsLocked(req.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = ids
+	}
+	var throws []ThrowCoordinate
+	for _, coord := range a.throwCoordinates {
+		switch {
+		case coord.CircleType != req.CircleType,
+			req.Station != "" && coord.Station != req.Station,
+			req.AthleteID != "" && coord.AthleteID != req.AthleteID,
+			req.Event != "" && coord.Event != req.Event,
+			sessionIDs != nil && !sessionIDs[coord.ID],
+			req.From != nil && coord.Timestamp.Before(*req.From),
+			req.To != nil && coord.Timestamp.After(*req.To):
+			continue
+		}
+		throws = append(throws, coord)
+	}
+	return throws, nil
+}
+
+// heatmapPoint places a throw in the chosen frame. Angle is always relative
+// to the station's sector centreline.
+func heatmapPoint(coord ThrowCoordinate, frame string, centreline float64) HeatmapPoint {
+	r := math.Hypot(coord.X, coord.Y)
+	relative := math.Remainder(math.Atan2(coord.Y, coord.X)-centreline, 2*math.Pi)
+	p := HeatmapPoint{
+		ID:        coord.ID,
+		AthleteID: coord.AthleteID,
+		Attempt:   coord.Attempt,
+		X:         coord.X,
+		Y:         coord.Y,
+		Distance:  coord.Distance,
+		Angle:     relative * 180 / math.Pi,
+	}
+	if frame == FrameSector {
+		p.X, p.Y = r*math.Cos(relative), r*math.Sin(relative)
+	}
+	return p
+}
+
+// heatmapBounds is the fixed extent for the circle type, or the throws'
+// extent when fitting to data
+func heatmapBounds(req HeatmapRequest, points []HeatmapPoint) HeatmapBounds {
+	if req.FitToData && len(points) > 0 {
+		b := HeatmapBounds{MinX: math.Inf(1), MaxX: math.Inf(-1), MinY: math.Inf(1), MaxY: math.Inf(-1)}
+		for _, p := range points {
+			x, y := p.X, p.Y
+			if req.Mode == HeatmapPolar {
+				x, y = p.Distance, p.Angle
+			}
+			b.MinX, b.MaxX = math.Min(b.MinX, x), math.Max(b.MaxX, x)
+			b.MinY, b.MaxY = math.Min(b.MinY, y), math.Max(b.MaxY, y)
+		}
+		return b
+	}
+	reach := defaultHeatmapRange
+	if r, ok := heatmapRanges[req.CircleType]; ok {
+		reach = r
+	}
+	halfSector := sectorAngles[req.CircleType] / 2
+	if halfSector == 0 {
+		halfSector = 45
+	}
+	switch {
+	case req.Mode == HeatmapPolar:
+		return HeatmapBounds{MinX: 0, MaxX: reach, MinY: -halfSector - polarAngleMargin, MaxY: halfSector + polarAngleMargin}
+	case req.Frame == FrameSector:
+		across := reach * math.Sin((halfSector+polarAngleMargin)*math.Pi/180)
+		return HeatmapBounds{MinX: 0, MaxX: reach, MinY: -across, MaxY: across}
+	}
+	return HeatmapBounds{MinX: -reach, MaxX: reach, MinY: -reach, MaxY: reach}
+}
+
+// buildHeatmap bins points. Request defaults must already be applied.
+func buildHeatmap(req HeatmapRequest, points []HeatmapPoint) (*Heatmap, error) {
+	h := &Heatmap{
+		CircleType:  req.CircleType,
+		Mode:        req.Mode,
+		Frame:       req.Frame,
+		GridSize:    req.CellSize,
+		Bounds:      heatmapBounds(req, points),
+		TotalThrows: len(points),
+		Points:      points,
+	}
+	stepY := req.CellSize
+	if req.Mode == HeatmapPolar {
+		h.AngleStep, stepY = req.AngleStep, req.AngleStep
+	}
+	h.GridWidth = int(math.Floor((h.Bounds.MaxX-h.Bounds.MinX)/req.CellSize)) + 1
+	h.GridHeight = int(math.Floor((h.Bounds.MaxY-h.Bounds.MinY)/stepY)) + 1
+	if h.GridWidth*h.GridHeight > maxHeatmapCells {
+		return nil, fmt.Errorf("heatmap would have %d cells, use a larger cell size", h.GridWidth*h.GridHeight)
+	}
+	h.Heatmap = make([][]float64, h.GridHeight)
+	for i := range h.Heatmap {
+		h.Heatmap[i] = make([]float64, h.GridWidth)
+	}
+
+	if req.Mode == HeatmapKDE {
+		h.Bandwidth = req.Bandwidth
+		h.Outside = kernelDensity(h, points)
+	} else {
+		for _, p := range points {
+			x, y := p.X, p.Y
+			if req.Mode == HeatmapPolar {
+				x, y = p.Distance, p.Angle
+			}
+			col := int(math.Floor((x - h.Bounds.MinX) / req.CellSize))
+			row := int(math.Floor((y - h.Bounds.MinY) / stepY))
+			if col < 0 || col >= h.GridWidth || row < 0 || row >= h.GridHeight {
+				h.Outside++
+				continue
+			}
+			h.Heatmap[row][col]++
+		}
+	}
+	for _, row := range h.Heatmap {
+		for _, v := range row {
+			h.MaxValue = math.Max(h.MaxValue, v)
+		}
+	}
+	return h, nil
+}
+
+// kernelDensity fills the grid with a Gaussian kernel density estimate at
+// each cell centre, normalised so the density integrates to 1. Kernels are
+// cut off at 4 bandwidths. Returns the number of throws outside the bounds.
+func kernelDensity(h *Heatmap, points []HeatmapPoint) int {
+	bw := h.Bandwidth
+	norm := 1 / (2 * math.Pi * bw * bw * float64(max(len(points), 1)))
+	reach := int(math.Ceil(4 * bw / h.GridSize))
+	outside := 0
+	for _, p := range points {
+		if p.X < h.Bounds.MinX || p.X > h.Bounds.MaxX || p.Y < h.Bounds.MinY || p.Y > h.Bounds.MaxY {
+			outside++
+		}
+		col := int(math.Floor((p.X - h.Bounds.MinX) / h.GridSize))
+		row := int(math.Floor((p.Y - h.Bounds.MinY) / h.GridSize))
+		for r := max(row-reach, 0); r <= min(row+reach, h.GridHeight-1); r++ {
+			cy := h.Bounds.MinY + (float64(r)+0.5)*h.GridSize
+			for c := max(col-reach, 0); c <= min(col+reach, h.GridWidth-1); c++ {
+				cx := h.Bounds.MinX + (float64(c)+0.5)*h.GridSize
+				d2 := (cx-p.X)*(cx-p.X) + (cy-p.Y)*(cy-p.Y)
+				h.Heatmap[r][c] += norm * math.Exp(-d2/(2*bw*bw))
+			}
+		}
+	}
+	return outside
+}
+
+// --- Wails Bindable Heatmap Functions ---
+
+// GetHeatmap bins the matching throws as a grid, kernel density or polar
+// (distance × angle) map. Without FitToData the bounds depend only on the
+// circle type, so maps from different sessions or athletes are comparable.
+func (a *App) GetHeatmap(req HeatmapRequest) (*Heatmap, error) {
+	if req.Mode == "" {
+		req.Mode = HeatmapGrid
+	}
+	if req.Mode != HeatmapGrid && req.Mode != HeatmapKDE && req.Mode != HeatmapPolar {
+		return nil, fmt.Errorf("unknown heatmap mode '%s'", req.Mode)
+	}
+	if req.Frame == "" || req.Mode == HeatmapPolar {
+		req.Frame = FrameSector
+	}
+	if req.Frame != FrameSector && req.Frame != FrameRaw {
+		return nil, fmt.Errorf("unknown heatmap frame '%s'", req.Frame)
+	}
+	if req.CellSize <= 0 {
+		req.CellSize = 1
+	}
+	if req.AngleStep <= 0 {
+		req.AngleStep = 2
+	}
+	if req.Bandwidth <= 0 {
+		req.Bandwidth = 2 * req.CellSize
+	}
+
+	a.stateMux.Lock()
+	throws, err := a.filterThrowsLocked(req)
+	centreline := a.throwCentrelineLocked()
+	points := make([]HeatmapPoint, len(throws))
+	for i, coord := range throws {
+		points[i] = heatmapPoint(coord, req.Frame, centreline(coord))
+	}
+	a.stateMux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	h, err := buildHeatmap(req, points)
+	if err != nil {
+		return nil, err
+	}
+	h.Coordinates = throws
+	log.Printf("Generated %s heatmap for %s: %dx%d with %d throws (%d outside)",
+		req.Mode, req.CircleType, h.GridWidth, h.GridHeight, len(throws), h.Outside)
+	return h, nil
+}
+
+// ExportHeatmapData is the original heatmap: a grid of counts in the raw
+// frame, bounded by the throws themselves
+func (a *App) ExportHeatmapData(circleType string, gridSize float64) (*Heatmap, error) {
+	h, err := a.GetHeatmap(HeatmapRequest{CircleType: circleType, Mode: HeatmapGrid, Frame: FrameRaw, CellSize: gridSize, FitToData: true})
+	if err != nil {
+		return nil, err
+	}
+	if h.TotalThrows == 0 {
+		return nil, fmt.Errorf("no coordinates found for %s", circleType)
+	}
+	return h, nil
+}
@@ -0,0 +1,592 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiServerConfigFile   = "api_server.json"
+	defaultAPIListenAddr  = "127.0.0.1:8765"
+	apiShutdownTimeout    = 5 * time.Second
+	apiMaxRequestBodySize = 1 << 20
+)
+
+// APIServerConfig controls the optional embedded HTTP/JSON API
+type APIServerConfig struct {
+	Enabled       bool   `json:"enabled"`       // Start automatically with the app
+	ListenAddress string `json:"listenAddress"` // 127.0.0.1:port for local only, 0.0.0.0:port for LAN
+	Token         string `json:"token"`         // Required as "Authorization: Bearer <token>"
+}
+
+type APIServerStatus struct {
+	Running       bool   `json:"running"`
+	ListenAddress string `json:"listenAddress"`
+	Token         string `json:"token"`
+	LastError     string `json:"lastError,omitempty"`
+}
+
+type apiServer struct {
+	server   *http.Server
+	listener net.Listener
+	config   APIServerConfig
+	err      error
+}
+
+func loadAPIServerConfig() APIServerConfig {
+	cfg := APIServerConfig{ListenAddress: defaultAPIListenAddr}
+	if err := loadJSONConfig(apiServerConfigFile, &cfg); err != nil {
+		log.Printf("Could not load API server config: %v", err)
+	}
+	return cfg
+}
+
+func generateAPIToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// --- Wails Bindable API Server Functions ---
+
+// StartAPIServer starts (or restarts) the HTTP API. A token is generated if
+// none is given. The config is persisted so Enabled servers start next run.
+func (a *App) StartAPIServer(cfg APIServerConfig) (*APIServerStatus, error) {
+	if cfg.ListenAddress == "" {
+		cfg.ListenAddress = defaultAPIListenAddr
+	}
+	if cfg.Token == "" {
+		token, err := generateAPIToken()
+		if err != nil {
+			return nil, fmt.Errorf("could not generate API token: %w", err)
+		}
+		cfg.Token = token
+	}
+
+	// Keep the running server until the new address is known to work
+	listener, err := net.Listen("tcp", cfg.ListenAddress)
+	if err != nil && a.apiListeningOn(cfg.ListenAddress) {
+		// Restarting on the same address, e.g. for a new token: the old
+		// server holds the port until it stops
+		a.StopAPIServer()
+		listener, err = net.Listen("tcp", cfg.ListenAddress)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on %s: %w", cfg.ListenAddress, err)
+	}
+	a.StopAPIServer()
+
+	srv := &apiServer{
+		listener: listener,
+		config:   cfg,
+		server: &http.Server{
+			Handler:           a.apiHandler(cfg.Token),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+	a.stateMux.Lock()
+	a.api = srv
+	a.apiConfig = cfg
+	a.stateMux.Unlock()
+	if err := saveJSONConfig(apiServerConfigFile, cfg); err != nil {
+		log.Printf("Could not save API server config: %v", err)
+	}
+
+	go func() {
+		err := srv.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("API server stopped: %v", err)
+			a.stateMux.Lock()
+			srv.err = err
+			a.stateMux.Unlock()
+		}
+	}()
+	log.Printf("API server listening on %s", listener.Addr())
+	status := a.GetAPIServerStatus()
+	return &status, nil
+}
+
+// apiListeningOn reports whether the running server was started on address
+func (a *App) apiListeningOn(address string) bool {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.api != nil && a.api.config.ListenAddress == address
+}
+
+func (a *App) StopAPIServer() error {
+	a.stateMux.Lock()
+	srv := a.api
+	a.api = nil
+	a.stateMux.Unlock()
+	if srv == nil {
+		return fmt.Errorf("API server not running")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), apiShutdownTimeout)
+	defer cancel()
+	err := srv.server.Shutdown(ctx)
+	// Shutdown only closes listeners Serve has picked up, so release the
+	// port now in case Serve has not started yet
+	srv.listener.Close()
+	return err
+}
+
+func (a *App) GetAPIServerStatus() APIServerStatus {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	status := APIServerStatus{ListenAddress: a.apiConfig.ListenAddress, Token: a.apiConfig.Token}
+	if a.api != nil {
+		status.Running = a.api.err == nil
+		if a.api.err != nil {
+			status.LastError = a.api.err.Error()
+		}
+	}
+	return status
+}
+
+// --- HTTP handlers ---
+
+// apiHandler maps the bound App methods onto JSON endpoints
+func (a *App) apiHandler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		a.stateMux.Lock()
+		demo := a.demoMode
+		a.stateMux.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"demoMode":    demo,
+			"devices":     a.GetDeviceStatuses(),
+			"stations":    a.ListStations(),
+			"scoreboards": a.GetScoreboardStatuses(),
+			"sync":        a.GetSyncStatus(),
+		})
+	})
+
+	// Calibration
+	mux.HandleFunc("GET /api/calibration/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.GetCalibration(r.PathValue("devType")))
+	})
+	mux.HandleFunc("PUT /api/calibration/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		var cal EDMCalibrationData
+		if !decodeJSON(w, r, &cal) {
+			return
+		}
+		respondErr(w, a.SaveCalibration(r.PathValue("devType"), cal))
+	})
+	mux.HandleFunc("DELETE /api/calibration/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.ResetCalibration(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/calibration/{devType}/centre", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.SetCircleCentre(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/calibration/{devType}/edge", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.VerifyCircleEdge(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/calibration/{devType}/centreline", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.MeasureSectorCentreline(r.PathValue("devType")))
+	})
+
+	// Measurement
+	mux.HandleFunc("POST /api/measure/{devType}/throw", func(w http.ResponseWriter, r *http.Request) {
+		result, err := a.MeasureThrow(r.PathValue("devType"))
+		respond(w)(map[string]string{"result": result}, err)
+	})
+	mux.HandleFunc("POST /api/measure/{devType}/wind", func(w http.ResponseWriter, r *http.Request) {
+		result, err := a.MeasureWind(r.PathValue("devType"))
+		respond(w)(map[string]string{"result": result}, err)
+	})
+	mux.HandleFunc("POST /api/measure/{devType}/foul", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.RecordFoul(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/measure/{devType}/pass", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.RecordPass(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/measure/{devType}/cancel", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.CancelMeasurement(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/athlete/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Bib     string `json:"bib"`
+			Name    string `json:"name"`
+			Attempt int    `json:"attempt"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.SetCurrentAthlete(r.PathValue("devType"), req.Bib, req.Name, req.Attempt))
+	})
+
+	// Stations and their sessions
+	mux.HandleFunc("GET /api/stations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.ListStations())
+	})
+	mux.HandleFunc("GET /api/session/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.GetCurrentSession(r.PathValue("devType")))
+	})
+	mux.HandleFunc("POST /api/session/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CircleType string `json:"circleType"`
+			SessionID  string `json:"sessionId"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.StartThrowSession(r.PathValue("devType"), req.CircleType, req.SessionID))
+	})
+	mux.HandleFunc("POST /api/session/{devType}/end", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.EndThrowSession(r.PathValue("devType")))
+	})
+	mux.HandleFunc("GET /api/standings/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.GetStandings(r.PathValue("devType"), r.URL.Query().Get("circleType")))
+	})
+
+	// Session archive
+	mux.HandleFunc("GET /api/sessions", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.ListSessions())
+	})
+	mux.HandleFunc("GET /api/sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.GetSession(r.PathValue("id")))
+	})
+	mux.HandleFunc("PUT /api/sessions/{id}/name", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Name string `json:"name"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.RenameSession(r.PathValue("id"), req.Name))
+	})
+	mux.HandleFunc("DELETE /api/sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.DeleteSession(r.PathValue("id")))
+	})
+	mux.HandleFunc("POST /api/sessions/merge", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			SessionIDs []string `json:"sessionIds"`
+			SessionID  string   `json:"sessionId"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respond(w)(a.MergeSessions(req.SessionIDs, req.SessionID))
+	})
+
+	// Training sessions
+	mux.HandleFunc("POST /api/training/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			CircleType string   `json:"circleType"`
+			SessionID  string   `json:"sessionId"`
+			Roster     []string `json:"roster"`
+			Implement  string   `json:"implement"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.StartTrainingSession(r.PathValue("devType"), req.CircleType, req.SessionID, req.Roster, req.Implement))
+	})
+	mux.HandleFunc("POST /api/training/{devType}/next", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.NextTrainingAthlete(r.PathValue("devType")))
+	})
+	mux.HandleFunc("PUT /api/training/{devType}/roster", func(w http.ResponseWriter, r *http.Request) {
+		var roster []string
+		if !decodeJSON(w, r, &roster) {
+			return
+		}
+		respondErr(w, a.SetTrainingRoster(r.PathValue("devType"), roster))
+	})
+	mux.HandleFunc("PUT /api/training/{devType}/implement", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Implement string `json:"implement"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.SetTrainingImplement(r.PathValue("devType"), req.Implement))
+	})
+	mux.HandleFunc("PUT /api/training/{devType}/tags", func(w http.ResponseWriter, r *http.Request) {
+		var tags []string
+		if !decodeJSON(w, r, &tags) {
+			return
+		}
+		respondErr(w, a.SetTrainingTags(r.PathValue("devType"), tags))
+	})
+	mux.HandleFunc("PUT /api/throws/{id}/tags", func(w http.ResponseWriter, r *http.Request) {
+		var tags []string
+		if !decodeJSON(w, r, &tags) {
+			return
+		}
+		respondErr(w, a.TagThrow(r.PathValue("id"), tags))
+	})
+
+	// Start lists. Imports take {"data": file contents, "event": ..., "mapping": ...}
+	mux.HandleFunc("GET /api/startlists", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.ListStartLists())
+	})
+	mux.HandleFunc("POST /api/startlists/{format}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data    string            `json:"data"`
+			Event   string            `json:"event"`
+			Mapping *CSVColumnMapping `json:"mapping"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		switch r.PathValue("format") {
+		case "csv":
+			if req.Mapping == nil {
+				respond(w)(a.PreviewCSVStartList(req.Data))
+				return
+			}
+			respond(w)(a.ImportCSVStartList(req.Data, *req.Mapping, req.Event))
+		case "lynx":
+			respond(w)(a.ImportLynxStartList(req.Data))
+		case "json":
+			respond(w)(a.ImportJSONStartList(req.Data, req.Event))
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "format must be csv, lynx or json"})
+		}
+	})
+	mux.HandleFunc("DELETE /api/startlists/{event}", func(w http.ResponseWriter, r *http.Request) {
+		respondErr(w, a.DeleteStartList(r.PathValue("event")))
+	})
+	mux.HandleFunc("POST /api/stations/{devType}/event", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Event string `json:"event"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.SetStationEvent(r.PathValue("devType"), req.Event))
+	})
+
+	// Results and export
+	mux.HandleFunc("GET /api/results", func(w http.ResponseWriter, r *http.Request) {
+		if circleType := r.URL.Query().Get("circleType"); circleType != "" {
+			respond(w)(a.ExportThrowCoordinatesForCircle(circleType))
+			return
+		}
+		respond(w)(a.ExportThrowCoordinates())
+	})
+	mux.HandleFunc("GET /api/results/statistics/{circleType}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.GetThrowStatistics(r.PathValue("circleType")))
+	})
+	mux.HandleFunc("GET /api/export/csv", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		opts := CSVExportOptions{
+			Units:            query.Get("units"),
+			DecimalSeparator: query.Get("decimal"),
+			TimeZone:         query.Get("tz"),
+		}
+		if columns := query.Get("columns"); columns != "" {
+			opts.Columns = strings.Split(columns, ",")
+		}
+		data, err := a.ExportThrowCoordinatesAsCSVWithOptions(opts)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="polyfield-throws.csv"`)
+		w.Write([]byte(data))
+	})
+	mux.HandleFunc("GET /api/results/events", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.ListResultEvents())
+	})
+	mux.HandleFunc("GET /api/results/event/{event}", func(w http.ResponseWriter, r *http.Request) {
+		respond(w)(a.GetEventResult(r.PathValue("event")))
+	})
+	mux.HandleFunc("GET /api/export/results/{event}", func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = ResultFormatGrid
+		}
+		data, err := a.ExportResults(r.PathValue("event"), format)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		extension := map[string]string{ResultFormatLynx: "lff", ResultFormatHyTek: "txt", ResultFormatGrid: "csv"}[format]
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="polyfield-results.%s"`, extension))
+		w.Write([]byte(data))
+	})
+	mux.HandleFunc("GET /api/analytics/{circleType}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.GetAthletesAnalytics(r.PathValue("circleType")))
+	})
+	mux.HandleFunc("GET /api/analytics/{circleType}/{athleteId}", func(w http.ResponseWriter, r *http.Request) {
+		window, _ := strconv.Atoi(r.URL.Query().Get("window"))
+		if r.URL.Query().Get("format") == "csv" {
+			data, err := a.ExportAthleteAnalyticsCSV(r.PathValue("athleteId"), r.PathValue("circleType"), window)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Write([]byte(data))
+			return
+		}
+		respond(w)(a.GetAthleteAnalytics(r.PathValue("athleteId"), r.PathValue("circleType"), window))
+	})
+	mux.HandleFunc("GET /api/competition-implements", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.GetCompetitionImplements())
+	})
+	mux.HandleFunc("PUT /api/competition-implements/{circleType}", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AthleteID string `json:"athleteId"`
+			Implement string `json:"implement"`
+		}
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respondErr(w, a.SetCompetitionImplement(r.PathValue("circleType"), req.AthleteID, req.Implement))
+	})
+	mux.HandleFunc("POST /api/heatmap", func(w http.ResponseWriter, r *http.Request) {
+		var req HeatmapRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+		respond(w)(a.GetHeatmap(req))
+	})
+	mux.HandleFunc("GET /api/export/geojson/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		data, err := a.ExportGeoJSON(r.PathValue("devType"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/geo+json")
+		w.Write([]byte(data))
+	})
+	mux.HandleFunc("GET /api/export/dxf/{devType}", func(w http.ResponseWriter, r *http.Request) {
+		data, err := a.ExportDXF(r.PathValue("devType"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/vnd.dxf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="polyfield-%s.dxf"`, r.PathValue("devType")))
+		w.Write([]byte(data))
+	})
+	mux.HandleFunc("GET /api/print/results/{event}", func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		writePrinted(w, format)(a.PrintResultSheet(r.PathValue("event"), format))
+	})
+	mux.HandleFunc("GET /api/print/card/{event}", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		rounds, _ := strconv.Atoi(query.Get("rounds"))
+		prefilled, _ := strconv.ParseBool(query.Get("prefilled"))
+		writePrinted(w, query.Get("format"))(a.PrintJudgesCard(r.PathValue("event"), rounds, prefilled, query.Get("format")))
+	})
+	mux.HandleFunc("GET /api/export/heatmap", func(w http.ResponseWriter, r *http.Request) {
+		gridSize, err := strconv.ParseFloat(r.URL.Query().Get("gridSize"), 64)
+		if err != nil || gridSize <= 0 {
+			gridSize = 1.0
+		}
+		respond(w)(a.ExportHeatmapData(r.URL.Query().Get("circleType"), gridSize))
+	})
+
+	// Replication from stations when this is the central instance
+	mux.HandleFunc("POST /api/sync", func(w http.ResponseWriter, r *http.Request) {
+		var batch SyncBatch
+		if !decodeJSON(w, r, &batch) {
+			return
+		}
+		respond(w)(a.mergeSyncBatch(batch))
+	})
+	mux.HandleFunc("GET /api/sync/results", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, a.GetSyncedResults())
+	})
+
+	// Live results feed (WebSocket), resumable with ?since=<seq>&epoch=<e>
+	mux.Handle("GET /api/feed", a.liveFeedHandler())
+
+	return requireToken(token, mux)
+}
+
+// requireToken rejects requests without the API token, accepted as a Bearer
+// header or a token query parameter (for browsers and simple scripts)
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		supplied := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if supplied == "" {
+			supplied = r.URL.Query().Get("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) != 1 {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid or missing API token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("API: failed to encode response: %v", err)
+	}
+}
+
+// writePrinted sends a rendered sheet with the content type for its format
+func writePrinted(w http.ResponseWriter, format string) func(data []byte, err error) {
+	return func(data []byte, err error) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if format == PrintFormatPDF {
+			w.Header().Set("Content-Type", "application/pdf")
+		} else {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		w.Write(data)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, errMeasurementCancelled) {
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// respond writes the value from an App method returning (T, error)
+func respond(w http.ResponseWriter) func(v interface{}, err error) {
+	return func(v interface{}, err error) {
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+// respondErr writes the outcome of an App method returning only error
+func respondErr(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, apiMaxRequestBodySize)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid JSON body: %v", err)})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Live feed message types
+const (
+	FeedHello   = "hello"   // First message on connect: {"latestSeq": n, "epoch": e}
+	FeedReset   = "reset"   // Requested history no longer buffered, refetch /api/results
+	FeedMark    = "mark"    // ThrowCoordinate
+	FeedFoul    = "foul"    // AttemptRecord
+	FeedPass    = "pass"    // AttemptRecord
+	FeedWind    = "wind"    // WindSampleEvent
+	FeedRanking = "ranking" // RankingUpdate, sent when a station's order changes
+)
+
+const (
+	feedHistorySize     = 1000
+	feedSubscriberQueue = 256
+)
+
+// FeedMessage is the JSON envelope sent to live feed subscribers. Seq
+// increases by one per message so a client can resume with ?since=<seq>.
+// Seq starts again from 1 when the app restarts, so clients should also pass
+// the hello message's epoch as &epoch=<e>.
+type FeedMessage struct {
+	Seq       uint64      `json:"seq"`
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// liveFeed buffers recent messages and fans them out to subscribers. It has
+// its own lock so publishing never waits on stateMux.
+type liveFeed struct {
+	mu          sync.Mutex
+	epoch       uint64 // Identifies this process's sequence numbers
+	seq         uint64
+	history     []FeedMessage
+	subscribers map[chan FeedMessage]struct{}
+	lastRanking map[string]string // station/circle type -> athlete order, to detect changes
+}
+
+func newLiveFeed() *liveFeed {
+	return &liveFeed{
+		epoch:       uint64(time.Now().UnixMilli()),
+		subscribers: make(map[chan FeedMessage]struct{}),
+		lastRanking: make(map[string]string),
+	}
+}
+
+func (f *liveFeed) publish(msgType string, data interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	msg := FeedMessage{Seq: f.seq, Type: msgType, Timestamp: time.Now().UTC(), Data: data}
+	f.history = append(f.history, msg)
+	if len(f.history) > feedHistorySize {
+		f.history = f.history[len(f.history)-feedHistorySize:]
+	}
+	for ch := range f.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			// Subscriber too slow; drop it so it reconnects and resumes
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// subscribe registers a subscriber and returns the buffered messages after
+// since. reset is true if some of those messages have already been
+// discarded, or since comes from an earlier run of the app (a different
+// epoch, or beyond the latest seq), in which case the whole buffer is sent.
+func (f *liveFeed) subscribe(since, epoch uint64) (ch chan FeedMessage, backlog []FeedMessage, latest uint64, reset bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch = make(chan FeedMessage, feedSubscriberQueue)
+	f.subscribers[ch] = struct{}{}
+	if since > 0 && ((epoch != 0 && epoch != f.epoch) || since > f.seq) {
+		reset, since = true, 0
+	}
+	if since > 0 && len(f.history) > 0 && f.history[0].Seq > since+1 {
+		reset = true
+	}
+	for _, msg := range f.history {
+		if msg.Seq > since {
+			backlog = append(backlog, msg)
+		}
+	}
+	return ch, backlog, f.seq, reset
+}
+
+func (f *liveFeed) unsubscribe(ch chan FeedMessage) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subscribers[ch]; ok {
+		delete(f.subscribers, ch)
+		close(ch)
+	}
+}
+
+// rankingChanged records the athlete order under key and reports whether it
+// differs from the last published order
+func (f *liveFeed) rankingChanged(key string, standings []Standing) bool {
+	order := ""
+	for _, s := range standings {
+		order += s.AthleteID + "@" + strconv.Itoa(s.Position) + ","
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lastRanking[key] == order {
+		return false
+	}
+	f.lastRanking[key] = order
+	return true
+}
+
+func (a *App) publishFeed(msgType string, data interface{}) {
+	a.feed.publish(msgType, data)
+}
+
+// publishMark sends a recorded mark and, if the order changed, the new ranking
+func (a *App) publishMark(coord ThrowCoordinate) {
+	a.feed.publish(FeedMark, coord)
+	a.stateMux.Lock()
+	standings := a.standingsLocked(coord.Station, coord.CircleType)
+	a.stateMux.Unlock()
+	if len(standings) > 0 && a.feed.rankingChanged(coord.Station+"/"+coord.CircleType, standings) {
+		a.feed.publish(FeedRanking, RankingUpdate{Station: coord.Station, CircleType: coord.CircleType, Standings: standings})
+	}
+}
+
+// serveLiveFeed streams feed messages over a WebSocket. Clients pass
+// ?since=<seq>&epoch=<e> to resume after the last message they received.
+func (a *App) serveLiveFeed(ws *websocket.Conn) {
+	defer ws.Close()
+	query := ws.Request().URL.Query()
+	since, _ := strconv.ParseUint(query.Get("since"), 10, 64)
+	epoch, _ := strconv.ParseUint(query.Get("epoch"), 10, 64)
+	ch, backlog, latest, reset := a.feed.subscribe(since, epoch)
+	defer a.feed.unsubscribe(ch)
+
+	if err := websocket.JSON.Send(ws, FeedMessage{Type: FeedHello, Timestamp: time.Now().UTC(),
+		Data: map[string]uint64{"latestSeq": latest, "epoch": a.feed.epoch}}); err != nil {
+		return
+	}
+	if reset {
+		if err := websocket.JSON.Send(ws, FeedMessage{Type: FeedReset, Timestamp: time.Now().UTC()}); err != nil {
+			return
+		}
+	}
+	for _, msg := range backlog {
+		if err := websocket.JSON.Send(ws, msg); err != nil {
+			return
+		}
+	}
+
+	// Detect the client going away; it sends nothing we need
+	closed := make(chan struct{})
+	go func() {
+		var discard string
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+		close(closed)
+	}()
+
+	for {
+		select {
+		case msg, ok := <-ch:
+			if !ok {
+				log.Printf("Live feed: dropped slow subscriber %s", ws.Request().RemoteAddr)
+				return
+			}
+			if err := websocket.JSON.Send(ws, msg); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// liveFeedHandler skips the Origin check; the API token already guards access
+func (a *App) liveFeedHandler() websocket.Server {
+	return websocket.Server{Handler: a.serveLiveFeed}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+var errMeasurementCancelled = errors.New("measurement cancelled")
+
+// beginMeasurement registers a cancellable context for a reading on
+// devType. The returned func must be called when the reading finishes.
+func (a *App) beginMeasurement(devType string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stateMux.Lock()
+	a.measurementSeq++
+	id := a.measurementSeq
+	if a.measurements[devType] == nil {
+		a.measurements[devType] = make(map[uint64]context.CancelFunc)
+	}
+	a.measurements[devType][id] = cancel
+	a.stateMux.Unlock()
+
+	return ctx, func() {
+		cancel()
+		a.stateMux.Lock()
+		delete(a.measurements[devType], id)
+		if len(a.measurements[devType]) == 0 {
+			delete(a.measurements, devType)
+		}
+		a.stateMux.Unlock()
+	}
+}
+
+// CancelMeasurement aborts every reading in progress or queued on devType.
+// Any late reply from the instrument is flushed before the next trigger, so
+// the device is ready for the next measurement straight away.
+func (a *App) CancelMeasurement(devType string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	pending := a.measurements[devType]
+	if len(pending) == 0 {
+		return fmt.Errorf("no measurement in progress on %s", devType)
+	}
+	for _, cancel := range pending {
+		cancel()
+	}
+	log.Printf("Cancelled %d measurement(s) on %s", len(pending), devType)
+	return nil
+}
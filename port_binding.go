@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"go.bug.st/serial/enumerator"
+)
+
+const portBindingsFile = "port_bindings.json"
+
+// SerialPortInfo describes a serial port and, for USB adapters, its identity
+type SerialPortInfo struct {
+	Name         string `json:"name"`
+	IsUSB        bool   `json:"isUsb"`
+	VID          string `json:"vid"`
+	PID          string `json:"pid"`
+	SerialNumber string `json:"serialNumber"`
+	Product      string `json:"product"`
+	BoundTo      string `json:"boundTo,omitempty"` // Device role bound to this adapter
+}
+
+// PortBinding ties a device role to a USB adapter so it survives the OS
+// handing out a different COM/tty name after a replug
+type PortBinding struct {
+	DeviceType   string         `json:"deviceType"`
+	SerialNumber string         `json:"serialNumber"`
+	VID          string         `json:"vid"`
+	PID          string         `json:"pid"`
+	Settings     SerialSettings `json:"settings"`
+	LastPortName string         `json:"lastPortName"`
+}
+
+func listSerialPortDetails() ([]*enumerator.PortDetails, error) {
+	return enumerator.GetDetailedPortsList()
+}
+
+// resolveBoundPort finds the port currently presented by the bound adapter
+func resolveBoundPort(binding PortBinding) (string, error) {
+	ports, err := listSerialPortDetails()
+	if err != nil {
+		return "", err
+	}
+	for _, p := range ports {
+		if p.IsUSB && strings.EqualFold(p.SerialNumber, binding.SerialNumber) &&
+			(binding.VID == "" || strings.EqualFold(p.VID, binding.VID)) &&
+			(binding.PID == "" || strings.EqualFold(p.PID, binding.PID)) {
+			return p.Name, nil
+		}
+	}
+	return "", fmt.Errorf("adapter %s for %s is not plugged in", binding.SerialNumber, binding.DeviceType)
+}
+
+// boundAdapterOn returns the USB serial number of devType's bound adapter if
+// that adapter is the one presenting portName
+func (a *App) boundAdapterOn(devType, portName string) (string, bool) {
+	a.stateMux.Lock()
+	binding, ok := a.portBindings[devType]
+	a.stateMux.Unlock()
+	if !ok {
+		return "", false
+	}
+	if binding.LastPortName == portName {
+		return binding.SerialNumber, true
+	}
+	current, err := resolveBoundPort(binding)
+	if err != nil || current != portName {
+		return "", false
+	}
+	return binding.SerialNumber, true
+}
+
+// recordBoundPortLocked remembers the port name a bound adapter was last
+// found on. Caller holds stateMux.
+func (a *App) recordBoundPortLocked(devType, portName string) {
+	binding, ok := a.portBindings[devType]
+	if !ok || binding.LastPortName == portName {
+		return
+	}
+	binding.LastPortName = portName
+	a.portBindings[devType] = binding
+	if err := saveJSONConfig(portBindingsFile, a.portBindings); err != nil {
+		log.Printf("Could not save port bindings: %v", err)
+	}
+}
+
+func loadPortBindings() map[string]PortBinding {
+	bindings := make(map[string]PortBinding)
+	if err := loadJSONConfig(portBindingsFile, &bindings); err != nil {
+		log.Printf("Could not load port bindings: %v", err)
+	}
+	return bindings
+}
+
+// --- Wails Bindable Port Binding Functions ---
+
+// ListSerialPortDetails returns every serial port with USB identity where
+// the platform reports it
+func (a *App) ListSerialPortDetails() ([]SerialPortInfo, error) {
+	ports, err := listSerialPortDetails()
+	if err != nil {
+		return nil, err
+	}
+	a.stateMux.Lock()
+	boundTo := make(map[string]string)
+	for devType, b := range a.portBindings {
+		boundTo[strings.ToUpper(b.SerialNumber)] = devType
+	}
+	a.stateMux.Unlock()
+
+	infos := make([]SerialPortInfo, 0, len(ports))
+	for _, p := range ports {
+		info := SerialPortInfo{
+			Name:         p.Name,
+			IsUSB:        p.IsUSB,
+			VID:          p.VID,
+			PID:          p.PID,
+			SerialNumber: p.SerialNumber,
+			Product:      p.Product,
+		}
+		if p.IsUSB && p.SerialNumber != "" {
+			info.BoundTo = boundTo[strings.ToUpper(p.SerialNumber)]
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// BindDeviceToPort binds a device role to whichever USB adapter currently
+// presents portName, keeping the settings it is connected with if any
+func (a *App) BindDeviceToPort(devType, portName string) (*PortBinding, error) {
+	ports, err := listSerialPortDetails()
+	if err != nil {
+		return nil, err
+	}
+	var details *enumerator.PortDetails
+	for _, p := range ports {
+		if p.Name == portName {
+			details = p
+		}
+	}
+	if details == nil {
+		return nil, fmt.Errorf("port %s not found", portName)
+	}
+	if !details.IsUSB || details.SerialNumber == "" {
+		return nil, fmt.Errorf("port %s has no USB serial number to bind to", portName)
+	}
+
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	settings := defaultSerialSettings()
+	if dev, ok := a.devices[devType]; ok && dev.ConnectionType == "serial" && dev.Address == portName {
+		settings = dev.serialSettings
+		dev.usbSerialNumber = details.SerialNumber
+	}
+	binding := PortBinding{
+		DeviceType:   devType,
+		SerialNumber: details.SerialNumber,
+		VID:          details.VID,
+		PID:          details.PID,
+		Settings:     settings,
+		LastPortName: portName,
+	}
+	a.portBindings[devType] = binding
+	if err := saveJSONConfig(portBindingsFile, a.portBindings); err != nil {
+		return nil, err
+	}
+	log.Printf("Bound %s to USB adapter %s (%s:%s)", devType, binding.SerialNumber, binding.VID, binding.PID)
+	return &binding, nil
+}
+
+func (a *App) UnbindDevice(devType string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if _, ok := a.portBindings[devType]; !ok {
+		return fmt.Errorf("%s is not bound to an adapter", devType)
+	}
+	delete(a.portBindings, devType)
+	if dev, ok := a.devices[devType]; ok {
+		dev.usbSerialNumber = ""
+	}
+	return saveJSONConfig(portBindingsFile, a.portBindings)
+}
+
+func (a *App) ListPortBindings() []PortBinding {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	bindings := make([]PortBinding, 0, len(a.portBindings))
+	for _, b := range a.portBindings {
+		bindings = append(bindings, b)
+	}
+	sort.Slice(bindings, func(i, j int) bool { return bindings[i].DeviceType < bindings[j].DeviceType })
+	return bindings
+}
+
+// ConnectBoundDevices connects every bound role whose adapter is plugged in
+// and not already connected. Returns one message per role. connectSerial
+// recognises the adapter and saves the port name it was found on.
+func (a *App) ConnectBoundDevices() []string {
+	bindings := a.ListPortBindings()
+	messages := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		a.stateMux.Lock()
+		_, connected := a.devices[binding.DeviceType]
+		a.stateMux.Unlock()
+		if connected {
+			continue
+		}
+		portName, err := resolveBoundPort(binding)
+		if err != nil {
+			messages = append(messages, err.Error())
+			continue
+		}
+		msg, err := a.connectSerial(binding.DeviceType, portName, binding.Settings)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("%s: %v", binding.DeviceType, err))
+			continue
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
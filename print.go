@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Printed result sheets and judges' cards. Both are a titled table with
+// signature blocks, built once as a printSheet and rendered as HTML (for the
+// browser's print dialog) or PDF.
+
+// Print formats
+const (
+	PrintFormatHTML = "html"
+	PrintFormatPDF  = "pdf"
+)
+
+const defaultCardRounds = 6
+
+// printCell is a table cell; Sub is a smaller second line, e.g. the wind
+type printCell struct {
+	Main string
+	Sub  string
+}
+
+type printSheet struct {
+	Title      string
+	Details    []string
+	Headers    []string
+	Widths     []float64 // Relative column widths
+	Rows       [][]printCell
+	Signatures []string // One signature block per official
+}
+
+// athleteName is how athletes appear on printed sheets: first name, then
+// surname in capitals
+func athleteName(first, last string) string {
+	return strings.TrimSpace(first + " " + strings.ToUpper(last))
+}
+
+// eventDetails is the line under the title: numbering and implement
+func eventDetails(eventNumber, round, flight, circleType string) string {
+	var parts []string
+	if eventNumber != "" {
+		parts = append(parts, "Event "+eventNumber)
+	}
+	if round != "" {
+		parts = append(parts, "Round "+round)
+	}
+	if flight != "" {
+		parts = append(parts, "Flight "+flight)
+	}
+	if circleType != "" {
+		parts = append(parts, circleType)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func attemptPrintCell(attempt *AttemptResult) printCell {
+	return printCell{Main: attemptCell(attempt, "X", "-"), Sub: formatWind(attemptWind(attempt))}
+}
+
+// resultSheetLocked lays out an event's placings and series. Caller holds
+// stateMux.
+func (a *App) resultSheetLocked(event string) (*printSheet, error) {
+	result, err := a.eventResultLocked(event)
+	if err != nil {
+		return nil, err
+	}
+	rounds := max(result.Rounds, 1)
+	sheet := &printSheet{
+		Title:      result.Event + " - Result",
+		Details:    []string{eventDetails(result.EventNumber, result.Round, result.Flight, result.CircleType)},
+		Headers:    []string{"Pos", "Bib", "Name", "Affiliation"},
+		Widths:     []float64{0.6, 0.7, 2.6, 2},
+		Signatures: []string{"Chief Judge", "Field Referee", "Recorder"},
+	}
+	for round := 1; round <= rounds; round++ {
+		sheet.Headers = append(sheet.Headers, strconv.Itoa(round))
+		sheet.Widths = append(sheet.Widths, 0.9)
+	}
+	sheet.Headers = append(sheet.Headers, "Best")
+	sheet.Widths = append(sheet.Widths, 1)
+
+	for _, athlete := range result.Athletes {
+		row := []printCell{
+			{Main: placeCell(athlete)},
+			{Main: athlete.Bib},
+			{Main: athleteName(athlete.FirstName, athlete.LastName)},
+			{Main: athlete.Affiliation},
+		}
+		for _, attempt := range seriesByRound(athlete, rounds) {
+			row = append(row, attemptPrintCell(attempt))
+		}
+		row = append(row, printCell{Main: bestCell(athlete), Sub: formatWind(athlete.BestWind)})
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet, nil
+}
+
+// judgesCardLocked lays out a start list for recording attempts by hand,
+// optionally filled in with the attempts already recorded. Caller holds
+// stateMux.
+func (a *App) judgesCardLocked(event string, rounds int, prefilled bool) (*printSheet, error) {
+	list, ok := a.startLists[event]
+	if !ok {
+		return nil, fmt.Errorf("no start list for '%s'", event)
+	}
+	if rounds <= 0 {
+		rounds = defaultCardRounds
+	}
+	recorded := make(map[string]AthleteResult)
+	circleType := ""
+	if prefilled {
+		if result, err := a.eventResultLocked(event); err == nil {
+			circleType = result.CircleType
+			for _, athlete := range result.Athletes {
+				recorded[athlete.Bib] = athlete
+			}
+		}
+	}
+
+	sheet := &printSheet{
+		Title:      list.Event + " - Judges' Card",
+		Details:    []string{eventDetails(list.EventNumber, list.Round, list.Flight, circleType), "Date: ____________    Venue: ________________________"},
+		Headers:    []string{"Order", "Bib", "Name", "Affiliation"},
+		Widths:     []float64{0.6, 0.7, 2.6, 2},
+		Signatures: []string{"Judge", "Recorder"},
+	}
+	for round := 1; round <= rounds; round++ {
+		sheet.Headers = append(sheet.Headers, strconv.Itoa(round))
+		sheet.Widths = append(sheet.Widths, 0.9)
+	}
+	sheet.Headers = append(sheet.Headers, "Best", "Pos")
+	sheet.Widths = append(sheet.Widths, 1, 0.6)
+
+	for i, entry := range list.Entries {
+		order := entry.Order
+		if order == 0 {
+			order = i + 1
+		}
+		row := []printCell{
+			{Main: strconv.Itoa(order)},
+			{Main: entry.Bib},
+			{Main: athleteName(entry.FirstName, entry.LastName)},
+			{Main: entry.Affiliation},
+		}
+		athlete, ok := recorded[entry.Bib]
+		for _, attempt := range seriesByRound(athlete, rounds) {
+			row = append(row, attemptPrintCell(attempt))
+		}
+		if ok && athlete.Status != ResultDidNotStart {
+			row = append(row, printCell{Main: bestCell(athlete), Sub: formatWind(athlete.BestWind)}, printCell{Main: placeCell(athlete)})
+		} else {
+			row = append(row, printCell{}, printCell{})
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	return sheet, nil
+}
+
+// --- HTML ---
+
+var printSheetTemplate = template.Must(template.New("sheet").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Title}}</title>
+<style>
+@page { size: A4 landscape; margin: 12mm; }
+body { font-family: Helvetica, Arial, sans-serif; font-size: 10pt; }
+h1 { font-size: 16pt; margin: 0 0 4pt; }
+p.details { margin: 0 0 2pt; }
+table { width: 100%; border-collapse: collapse; margin-top: 8pt; }
+th, td { border: 0.5pt solid #000; padding: 3pt 4pt; height: 22pt; vertical-align: top; }
+th { background: #eee; text-align: left; }
+tr { page-break-inside: avoid; }
+small { display: block; font-size: 7pt; }
+.signatures { display: flex; gap: 24pt; margin-top: 24pt; page-break-inside: avoid; }
+.signature { flex: 1; }
+.signature div { border-bottom: 0.5pt solid #000; height: 20pt; margin-bottom: 2pt; }
+.printed { margin-top: 12pt; font-size: 7pt; color: #555; }
+</style></head><body>
+<h1>{{.Sheet.Title}}</h1>
+{{range .Sheet.Details}}{{if .}}<p class="details">{{.}}</p>{{end}}{{end}}
+<table>
+<colgroup>{{range .Widths}}<col style="width: {{.}}%">{{end}}</colgroup>
+<tr>{{range .Sheet.Headers}}<th>{{.}}</th>{{end}}</tr>
+{{range .Sheet.Rows}}<tr>{{range .}}<td>{{.Main}}{{if .Sub}}<small>{{.Sub}}</small>{{end}}</td>{{end}}</tr>
+{{end}}</table>
+<div class="signatures">{{range .Sheet.Signatures}}
+<div class="signature"><strong>{{.}}</strong><div></div>Name<div></div>Signature<div></div>Time</div>{{end}}
+</div>
+<p class="printed">Printed {{.Printed}} by PolyField</p>
+</body></html>
+`))
+
+func renderSheetHTML(sheet *printSheet, printed time.Time) ([]byte, error) {
+	total := 0.0
+	for _, w := range sheet.Widths {
+		total += w
+	}
+	widths := make([]string, len(sheet.Widths))
+	for i, w := range sheet.Widths {
+		widths[i] = strconv.FormatFloat(100*w/total, 'f', 1, 64)
+	}
+	var b bytes.Buffer
+	err := printSheetTemplate.Execute(&b, map[string]interface{}{
+		"Title":   sheet.Title,
+		"Sheet":   sheet,
+		"Widths":  widths,
+		"Printed": printed.Format("2 Jan 2006 15:04"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not render sheet: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// --- PDF ---
+
+const (
+	pdfMargin         = 36.0
+	pdfHeaderHeight   = 18.0
+	pdfRowHeight      = 16.0
+	pdfRowHeightSub   = 24.0
+	pdfSignatureSpace = 90.0
+)
+
+// renderSheetPDF draws the sheet on landscape A4 pages, repeating the header
+// row on each page and finishing with the signature blocks
+func renderSheetPDF(sheet *printSheet, printed time.Time) []byte {
+	doc := newPDFDocument(pdfA4Height, pdfA4Width)
+	width := doc.width - 2*pdfMargin
+	total := 0.0
+	for _, w := range sheet.Widths {
+		total += w
+	}
+	xs := []float64{pdfMargin}
+	for _, w := range sheet.Widths {
+		xs = append(xs, xs[len(xs)-1]+width*w/total)
+	}
+	bottom := doc.height - pdfMargin
+
+	// Start a page with the title and the header row; returns where rows begin
+	startPage := func(pageNumber int) float64 {
+		doc.addPage()
+		title := sheet.Title
+		if pageNumber > 1 {
+			title += fmt.Sprintf(" (page %d)", pageNumber)
+		}
+		doc.text(pdfMargin, pdfMargin+12, 16, true, title)
+		y := pdfMargin + 30
+		for _, detail := range sheet.Details {
+			if detail != "" {
+				doc.text(pdfMargin, y, 10, false, detail)
+				y += 14
+			}
+		}
+		y += 4
+		doc.line(pdfMargin, y, xs[len(xs)-1], y, 0.8)
+		for i, header := range sheet.Headers {
+			doc.text(xs[i]+3, y+12, 9, true, fitText(header, xs[i+1]-xs[i]-6, 9))
+		}
+		return y + pdfHeaderHeight
+	}
+	// Close the table on the current page with its column rules
+	endTable := func(top, y float64) {
+		doc.line(pdfMargin, y, xs[len(xs)-1], y, 0.8)
+		for _, x := range xs {
+			doc.line(x, top-pdfHeaderHeight, x, y, 0.5)
+		}
+	}
+
+	pageNumber := 1
+	top := startPage(pageNumber)
+	y := top
+	for _, row := range sheet.Rows {
+		height := pdfRowHeight
+		for _, cell := range row {
+			if cell.Sub != "" {
+				height = pdfRowHeightSub
+			}
+		}
+		if y+height > bottom {
+			endTable(top, y)
+			pageNumber++
+			top = startPage(pageNumber)
+			y = top
+		}
+		doc.line(pdfMargin, y, xs[len(xs)-1], y, 0.5)
+		for i, cell := range row {
+			cellWidth := xs[i+1] - xs[i] - 6
+			doc.text(xs[i]+3, y+12, 10, false, fitText(cell.Main, cellWidth, 10))
+			if cell.Sub != "" {
+				doc.text(xs[i]+3, y+21, 7, false, fitText(cell.Sub, cellWidth, 7))
+			}
+		}
+		y += height
+	}
+	endTable(top, y)
+
+	if y+pdfSignatureSpace > bottom {
+		doc.addPage()
+		y = pdfMargin
+	}
+	y += 28
+	blockWidth := (width - 24*float64(len(sheet.Signatures)-1)) / float64(max(len(sheet.Signatures), 1))
+	for i, label := range sheet.Signatures {
+		x := pdfMargin + float64(i)*(blockWidth+24)
+		doc.text(x, y, 10, true, label)
+		for j, field := range []string{"Name", "Signature", "Time"} {
+			lineY := y + 20 + float64(j)*18
+			doc.line(x+50, lineY, x+blockWidth, lineY, 0.5)
+			doc.text(x, lineY, 8, false, field)
+		}
+	}
+	doc.text(pdfMargin, doc.height-pdfMargin/2, 7, false, "Printed "+printed.Format("2 Jan 2006 15:04")+" by PolyField")
+	return doc.bytes()
+}
+
+func renderSheet(sheet *printSheet, format string) ([]byte, error) {
+	printed := time.Now()
+	switch format {
+	case PrintFormatHTML, "":
+		return renderSheetHTML(sheet, printed)
+	case PrintFormatPDF:
+		return renderSheetPDF(sheet, printed), nil
+	}
+	return nil, fmt.Errorf("unknown print format '%s' (use html or pdf)", format)
+}
+
+// --- Wails Bindable Print Functions ---
+
+// PrintResultSheet renders an event's official result sheet as "html" or
+// "pdf", with signature blocks for the officials
+func (a *App) PrintResultSheet(event string, format string) ([]byte, error) {
+	a.stateMux.Lock()
+	sheet, err := a.resultSheetLocked(event)
+	a.stateMux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Printing result sheet for %s (%s)", event, format)
+	return renderSheet(sheet, format)
+}
+
+// PrintJudgesCard renders a judges' card for an event's start list as "html"
+// or "pdf". rounds defaults to 6; prefilled copies in the attempts already
+// recorded.
+func (a *App) PrintJudgesCard(event string, rounds int, prefilled bool, format string) ([]byte, error) {
+	a.stateMux.Lock()
+	sheet, err := a.judgesCardLocked(event, rounds, prefilled)
+	a.stateMux.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Printing judges' card for %s (%s)", event, format)
+	return renderSheet(sheet, format)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Attempt outcomes that produce no mark
+const (
+	AttemptFoul = "FOUL"
+	AttemptPass = "PASS"
+)
+
+// attemptFeedKinds maps an attempt kind to its live feed message type
+var attemptFeedKinds = map[string]string{AttemptFoul: FeedFoul, AttemptPass: FeedPass}
+
+// attemptBoardMarks is what the scoreboard shows for each kind
+var attemptBoardMarks = map[string]string{AttemptFoul: "X", AttemptPass: "-"}
+
+// AttemptRecord is an attempt without a measured mark
+type AttemptRecord struct {
+	ID         string    `json:"id"`
+	Origin     string    `json:"origin,omitempty"`
+	Kind       string    `json:"kind"` // FOUL or PASS
+	AthleteID  string    `json:"athleteId"`
+	Station    string    `json:"station,omitempty"`
+	Event      string    `json:"event,omitempty"`
+	Attempt    int       `json:"attempt"`
+	Training   bool      `json:"training,omitempty"`
+	CircleType string    `json:"circleType"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Standing is one athlete's place by best mark
+type Standing struct {
+	Position  int     `json:"position"`
+	AthleteID string  `json:"athleteId"`
+	Best      float64 `json:"best"`
+	Marks     int     `json:"marks"`
+}
+
+// rankCoordinates orders athletes by best mark. Ties share a position.
+// Throws without an athlete are ignored.
+func rankCoordinates(coords []ThrowCoordinate) []Standing {
+	byAthlete := make(map[string]*Standing)
+	for _, coord := range coords {
+		if coord.AthleteID == "" || coord.Training {
+			continue
+		}
+		s, ok := byAthlete[coord.AthleteID]
+		if !ok {
+			s = &Standing{AthleteID: coord.AthleteID, Best: coord.Distance}
+			byAthlete[coord.AthleteID] = s
+		}
+		s.Marks++
+		if coord.Distance > s.Best {
+			s.Best = coord.Distance
+		}
+	}
+	standings := make([]Standing, 0, len(byAthlete))
+	for _, s := range byAthlete {
+		standings = append(standings, *s)
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Best != standings[j].Best {
+			return standings[i].Best > standings[j].Best
+		}
+		return standings[i].AthleteID < standings[j].AthleteID
+	})
+	for i := range standings {
+		standings[i].Position = i + 1
+		if i > 0 && standings[i].Best == standings[i-1].Best {
+			standings[i].Position = standings[i-1].Position
+		}
+	}
+	return standings
+}
+
+// RankingUpdate is a new order for a station (or, on the central instance,
+// a whole event), sent on the live feed
+type RankingUpdate struct {
+	Station    string     `json:"station,omitempty"`
+	CircleType string     `json:"circleType"`
+	Standings  []Standing `json:"standings"`
+}
+
+// standingsLocked ranks the station's session, or all throws of circleType
+// when it has no active session. Caller holds stateMux.
+func (a *App) standingsLocked(devType, circleType string) []Standing {
+	if st, ok := a.findStationLocked(devType); ok && st.session != nil {
+		return rankCoordinates(st.session.Coordinates)
+	}
+	return a.circleStandingsLocked(circleType)
+}
+
+// circleStandingsLocked ranks every recorded throw of circleType. Caller
+// holds stateMux.
+func (a *App) circleStandingsLocked(circleType string) []Standing {
+	var coords []ThrowCoordinate
+	for _, coord := range a.throwCoordinates {
+		if coord.CircleType == circleType {
+			coords = append(coords, coord)
+		}
+	}
+	return rankCoordinates(coords)
+}
+
+// --- Wails Bindable Results Functions ---
+
+// recordAttempt records a mark-less attempt for the station's current
+// athlete and attempt
+func (a *App) recordAttempt(devType, kind string) error {
+	a.stateMux.Lock()
+	cal, exists := a.CalibrationStore[devType]
+	if !exists {
+		a.stateMux.Unlock()
+		return fmt.Errorf("no calibration for %s", devType)
+	}
+	st := a.stationLocked(devType)
+	athlete := st.board
+	record := AttemptRecord{
+		ID:         newRecordID(),
+		Origin:     a.sync.origin(),
+		Kind:       kind,
+		AthleteID:  athlete.Bib,
+		Station:    devType,
+		Event:      st.event,
+		Attempt:    athlete.Attempt,
+		Training:   st.training(),
+		CircleType: cal.SelectedCircleType,
+		Timestamp:  time.Now().UTC(),
+	}
+	a.attempts = append(a.attempts, record)
+	a.sync.enqueue(func(b *SyncBatch) { b.Attempts = append(b.Attempts, record) })
+	a.stateMux.Unlock()
+
+	a.publishFeed(attemptFeedKinds[kind], record)
+	a.recordScoreboardMark(devType, attemptBoardMarks[kind])
+	log.Printf("Recorded %s for athlete '%s' attempt %d", strings.ToLower(kind), record.AthleteID, record.Attempt)
+	return nil
+}
+
+// RecordFoul records a foul for the station's current athlete and attempt
+func (a *App) RecordFoul(devType string) error {
+	return a.recordAttempt(devType, AttemptFoul)
+}
+
+// RecordPass records that the station's current athlete passed the attempt
+func (a *App) RecordPass(devType string) error {
+	return a.recordAttempt(devType, AttemptPass)
+}
+
+// GetStandings returns the station's current ranking, falling back to all
+// throws of circleType when it has no session
+func (a *App) GetStandings(devType string, circleType string) []Standing {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.standingsLocked(devType, circleType)
+}
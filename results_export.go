@@ -0,0 +1,454 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Result export formats for ExportResults
+const (
+	ResultFormatLynx  = "lff"   // FieldLynx-style comma separated result file
+	ResultFormatHyTek = "hytek" // Hy-Tek style semicolon separated results
+	ResultFormatGrid  = "grid"  // Per-athlete attempt grid CSV
+)
+
+// AttemptMark is a measured attempt in an athlete's series
+const AttemptMark = "MARK"
+
+// Result statuses for athletes without a place
+const (
+	ResultNoMark      = "NM"  // Only fouls and passes
+	ResultDidNotStart = "DNS" // On the start list with no attempts
+)
+
+// windMatchWindow is how far apart a wind reading and an attempt can be for
+// the reading to count as that attempt's wind
+const windMatchWindow = time.Minute
+
+// AttemptResult is one attempt in an athlete's series
+type AttemptResult struct {
+	Attempt  int      `json:"attempt"`
+	Kind     string   `json:"kind"` // MARK, FOUL or PASS
+	Distance float64  `json:"distance,omitempty"`
+	Wind     *float64 `json:"wind,omitempty"` // Nearest wind reading, if one was measured
+}
+
+// AthleteResult is an athlete's series and placing
+type AthleteResult struct {
+	Place       int             `json:"place"` // 0 when the athlete has no place
+	Status      string          `json:"status,omitempty"`
+	Bib         string          `json:"bib"`
+	FirstName   string          `json:"firstName"`
+	LastName    string          `json:"lastName"`
+	Affiliation string          `json:"affiliation"`
+	Order       int             `json:"order"`
+	Best        float64         `json:"best"`
+	BestWind    *float64        `json:"bestWind,omitempty"`
+	Attempts    []AttemptResult `json:"attempts"`
+}
+
+// EventResult is an event's full result, in place order
+type EventResult struct {
+	Event       string          `json:"event"`
+	CircleType  string          `json:"circleType"`
+	EventNumber string          `json:"eventNumber,omitempty"`
+	Round       string          `json:"round,omitempty"`
+	Flight      string          `json:"flight,omitempty"`
+	Rounds      int             `json:"rounds"`  // Longest series
+	HasWind     bool            `json:"hasWind"` // Any attempt has a wind reading
+	Athletes    []AthleteResult `json:"athletes"`
+}
+
+// resultEventKey groups results by start list event, or by circle type for
+// throws measured without one
+func resultEventKey(event, circleType string) string {
+	if event != "" {
+		return event
+	}
+	return circleType
+}
+
+// truncateMark rounds a distance down to the centimetre, as field event
+// marks are recorded
+func truncateMark(distance float64) float64 {
+	return math.Floor(distance*100+1e-9) / 100
+}
+
+func formatMark(distance float64) string {
+	return strconv.FormatFloat(distance, 'f', 2, 64)
+}
+
+// attemptWind is the attempt's wind, nil for an empty round
+func attemptWind(attempt *AttemptResult) *float64 {
+	if attempt == nil {
+		return nil
+	}
+	return attempt.Wind
+}
+
+func formatWind(wind *float64) string {
+	if wind == nil {
+		return ""
+	}
+	return fmt.Sprintf("%+.1f", *wind)
+}
+
+// nearestWindLocked finds the wind reading from the same instance closest to
+// t, within windMatchWindow either side. Caller holds stateMux.
+func (a *App) nearestWindLocked(origin string, t time.Time) *float64 {
+	local := a.sync.origin()
+	var best *WindSampleEvent
+	for i := range a.windSamples {
+		sample := &a.windSamples[i]
+		sampleOrigin := sample.Origin
+		if sampleOrigin == "" {
+			sampleOrigin = local
+		}
+		gap := sample.Timestamp.Sub(t).Abs()
+		if sampleOrigin != origin || gap > windMatchWindow {
+			continue
+		}
+		if best == nil || gap < best.Timestamp.Sub(t).Abs() {
+			best = sample
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	value := best.Value
+	return &value
+}
+
+// betterSeries compares valid marks longest first, so a tie on best is
+// broken by the second best and so on
+func betterSeries(a, b []float64) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+	return len(a) - len(b)
+}
+
+// eventResultLocked builds the result for an event from its marks, fouls and
+// passes. Attempts without an athlete cannot be placed and are left out.
+// Caller holds stateMux.
+func (a *App) eventResultLocked(event string) (*EventResult, error) {
+	type timedAttempt struct {
+		AttemptResult
+		origin    string
+		timestamp time.Time
+	}
+	series := make(map[string][]timedAttempt)
+	result := &EventResult{Event: event}
+	for _, coord := range a.throwCoordinates {
+		if resultEventKey(coord.Event, coord.CircleType) != event || coord.AthleteID == "" || coord.Training {
+			continue
+		}
+		result.CircleType = coord.CircleType
+		series[coord.AthleteID] = append(series[coord.AthleteID], timedAttempt{
+			AttemptResult: AttemptResult{Attempt: coord.Attempt, Kind: AttemptMark, Distance: truncateMark(coord.Distance)},
+			origin:        coord.Origin,
+			timestamp:     coord.Timestamp,
+		})
+	}
+	for _, record := range a.attempts {
+		if resultEventKey(record.Event, record.CircleType) != event || record.AthleteID == "" || record.Training {
+			continue
+		}
+		result.CircleType = record.CircleType
+		series[record.AthleteID] = append(series[record.AthleteID], timedAttempt{
+			AttemptResult: AttemptResult{Attempt: record.Attempt, Kind: record.Kind},
+			origin:        record.Origin,
+			timestamp:     record.Timestamp,
+		})
+	}
+
+	entries := make(map[string]StartListEntry)
+	if list, ok := a.startLists[event]; ok {
+		result.EventNumber, result.Round, result.Flight = list.EventNumber, list.Round, list.Flight
+		for _, entry := range list.Entries {
+			entries[entry.Bib] = entry
+			if _, ok := series[entry.Bib]; !ok {
+				series[entry.Bib] = nil
+			}
+		}
+	}
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no results for '%s'", event)
+	}
+
+	valid := make(map[string][]float64)
+	for bib, attempts := range series {
+		entry, ok := entries[bib]
+		if !ok {
+			entry = StartListEntry{Bib: bib}
+		}
+		athlete := AthleteResult{
+			Bib:         bib,
+			FirstName:   entry.FirstName,
+			LastName:    entry.LastName,
+			Affiliation: entry.Affiliation,
+			Order:       entry.Order,
+		}
+
+		// Unnumbered attempts follow on from the last; a re-measured attempt
+		// replaces the earlier record
+		sort.Slice(attempts, func(i, j int) bool { return attempts[i].timestamp.Before(attempts[j].timestamp) })
+		numbered := make(map[int]AttemptResult)
+		last := 0
+		for _, attempt := range attempts {
+			if attempt.Attempt <= 0 {
+				attempt.Attempt = last + 1
+			}
+			last = max(last, attempt.Attempt)
+			if attempt.Kind == AttemptMark {
+				attempt.Wind = a.nearestWindLocked(attempt.origin, attempt.timestamp)
+			}
+			numbered[attempt.Attempt] = attempt.AttemptResult
+		}
+		for _, attempt := range numbered {
+			athlete.Attempts = append(athlete.Attempts, attempt)
+		}
+		sort.Slice(athlete.Attempts, func(i, j int) bool { return athlete.Attempts[i].Attempt < athlete.Attempts[j].Attempt })
+
+		for _, attempt := range athlete.Attempts {
+			result.HasWind = result.HasWind || attempt.Wind != nil
+			if attempt.Kind != AttemptMark {
+				continue
+			}
+			valid[bib] = append(valid[bib], attempt.Distance)
+			if attempt.Distance > athlete.Best {
+				athlete.Best, athlete.BestWind = attempt.Distance, attempt.Wind
+			}
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(valid[bib])))
+		switch {
+		case len(athlete.Attempts) == 0:
+			athlete.Status = ResultDidNotStart
+		case len(valid[bib]) == 0:
+			athlete.Status = ResultNoMark
+		}
+		if n := len(athlete.Attempts); n > 0 {
+			result.Rounds = max(result.Rounds, athlete.Attempts[n-1].Attempt)
+		}
+		result.Athletes = append(result.Athletes, athlete)
+	}
+
+	athletes := result.Athletes
+	sort.SliceStable(athletes, func(i, j int) bool {
+		if c := betterSeries(valid[athletes[i].Bib], valid[athletes[j].Bib]); c != 0 {
+			return c > 0
+		}
+		if athletes[i].Status != athletes[j].Status {
+			return athletes[j].Status == ResultDidNotStart // NM before DNS
+		}
+		if athletes[i].Order != athletes[j].Order {
+			return athletes[i].Order < athletes[j].Order
+		}
+		return athletes[i].Bib < athletes[j].Bib
+	})
+	for i := range athletes {
+		if athletes[i].Status != "" {
+			continue
+		}
+		athletes[i].Place = i + 1
+		if i > 0 && betterSeries(valid[athletes[i].Bib], valid[athletes[i-1].Bib]) == 0 {
+			athletes[i].Place = athletes[i-1].Place
+		}
+	}
+	return result, nil
+}
+
+// attemptCell is an attempt as written in a result file, with the codes the
+// format uses for fouls and passes
+func attemptCell(attempt *AttemptResult, foul, pass string) string {
+	switch {
+	case attempt == nil:
+		return ""
+	case attempt.Kind == AttemptFoul:
+		return foul
+	case attempt.Kind == AttemptPass:
+		return pass
+	}
+	return formatMark(attempt.Distance)
+}
+
+// seriesByRound lays out an athlete's attempts one per round, nil for rounds
+// with no record
+func seriesByRound(athlete AthleteResult, rounds int) []*AttemptResult {
+	byRound := make([]*AttemptResult, rounds)
+	for i := range athlete.Attempts {
+		if n := athlete.Attempts[i].Attempt; n >= 1 && n <= rounds {
+			byRound[n-1] = &athlete.Attempts[i]
+		}
+	}
+	return byRound
+}
+
+// placeCell is the place, or the status when the athlete has none
+func placeCell(athlete AthleteResult) string {
+	if athlete.Status != "" {
+		return athlete.Status
+	}
+	return strconv.Itoa(athlete.Place)
+}
+
+func bestCell(athlete AthleteResult) string {
+	if athlete.Status != "" {
+		return ""
+	}
+	return formatMark(athlete.Best)
+}
+
+func writeRecords(records [][]string, comma rune) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+	if err := w.WriteAll(records); err != nil {
+		return "", fmt.Errorf("could not write results: %w", err)
+	}
+	return b.String(), nil
+}
+
+// encodeLynxResults writes a FieldLynx-style .lff: the event line
+// "event,round,flight,name" as in the start list, then one line per athlete
+// "place,bib,order,last,first,affiliation,best,bestWind" followed by a
+// mark,wind pair per round. Fouls are X and passes -.
+func encodeLynxResults(result *EventResult) (string, error) {
+	eventNumber, round, flight := result.EventNumber, result.Round, result.Flight
+	if eventNumber == "" {
+		eventNumber, round, flight = "1", "1", "1"
+	}
+	records := [][]string{{eventNumber, round, flight, result.Event}}
+	for _, athlete := range result.Athletes {
+		record := []string{
+			placeCell(athlete), athlete.Bib, strconv.Itoa(athlete.Order),
+			athlete.LastName, athlete.FirstName, athlete.Affiliation,
+			bestCell(athlete), formatWind(athlete.BestWind),
+		}
+		for _, attempt := range seriesByRound(athlete, result.Rounds) {
+			record = append(record, attemptCell(attempt, "X", "-"), formatWind(attemptWind(attempt)))
+		}
+		records = append(records, record)
+	}
+	return writeRecords(records, ',')
+}
+
+// encodeHyTekResults writes Hy-Tek style semicolon records, one per athlete:
+// "bib;last;first;team;place;best;bestWind;" then the series, with F for a
+// foul and P for a pass. Wind follows each mark only when it was measured.
+func encodeHyTekResults(result *EventResult) (string, error) {
+	var records [][]string
+	for _, athlete := range result.Athletes {
+		record := []string{
+			athlete.Bib, athlete.LastName, athlete.FirstName, athlete.Affiliation,
+			placeCell(athlete), bestCell(athlete), formatWind(athlete.BestWind),
+		}
+		for _, attempt := range seriesByRound(athlete, result.Rounds) {
+			record = append(record, attemptCell(attempt, "F", "P"))
+			if result.HasWind {
+				record = append(record, formatWind(attemptWind(attempt)))
+			}
+		}
+		records = append(records, record)
+	}
+	return writeRecords(records, ';')
+}
+
+// encodeAttemptGrid writes a CSV with a header and one row per athlete, the
+// series in R1..Rn columns. Wind columns are included only when measured.
+func encodeAttemptGrid(result *EventResult) (string, error) {
+	header := []string{"Place", "Bib", "Last Name", "First Name", "Affiliation", "Best"}
+	if result.HasWind {
+		header = append(header, "Best Wind")
+	}
+	for round := 1; round <= result.Rounds; round++ {
+		header = append(header, fmt.Sprintf("R%d", round))
+		if result.HasWind {
+			header = append(header, fmt.Sprintf("R%d Wind", round))
+		}
+	}
+	records := [][]string{header}
+	for _, athlete := range result.Athletes {
+		record := []string{placeCell(athlete), athlete.Bib, athlete.LastName, athlete.FirstName, athlete.Affiliation, bestCell(athlete)}
+		if result.HasWind {
+			record = append(record, formatWind(athlete.BestWind))
+		}
+		for _, attempt := range seriesByRound(athlete, result.Rounds) {
+			record = append(record, attemptCell(attempt, "X", "-"))
+			if result.HasWind {
+				record = append(record, formatWind(attemptWind(attempt)))
+			}
+		}
+		records = append(records, record)
+	}
+	return writeRecords(records, ',')
+}
+
+var resultEncoders = map[string]func(*EventResult) (string, error){
+	ResultFormatLynx:  encodeLynxResults,
+	ResultFormatHyTek: encodeHyTekResults,
+	ResultFormatGrid:  encodeAttemptGrid,
+}
+
+// --- Wails Bindable Result Export Functions ---
+
+// ListResultEvents returns the events that have results: start list events,
+// and circle types for throws measured without one
+func (a *App) ListResultEvents() []string {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	events := make(map[string]bool)
+	for _, coord := range a.throwCoordinates {
+		if !coord.Training {
+			events[resultEventKey(coord.Event, coord.CircleType)] = true
+		}
+	}
+	for _, record := range a.attempts {
+		if !record.Training {
+			events[resultEventKey(record.Event, record.CircleType)] = true
+		}
+	}
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetEventResult returns an event's placings and series
+func (a *App) GetEventResult(event string) (*EventResult, error) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.eventResultLocked(event)
+}
+
+// ExportResults writes an event's results in a meet-manager import format:
+// "lff", "hytek" or "grid"
+func (a *App) ExportResults(event string, format string) (string, error) {
+	encode, ok := resultEncoders[format]
+	if !ok {
+		return "", fmt.Errorf("unknown result format '%s'", format)
+	}
+	result, err := a.GetEventResult(event)
+	if err != nil {
+		return "", err
+	}
+	data, err := encode(result)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Exported %s results for %s (%d athletes)", format, event, len(result.Athletes))
+	return data, nil
+}
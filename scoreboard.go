@@ -0,0 +1,358 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Framing bytes shared by the packetised scoreboard protocols
+const (
+	asciiSTX = 0x02
+	asciiETX = 0x03
+	asciiCR  = 0x0d
+)
+
+const defaultScoreboardProtocol = "plain"
+
+// ScoreboardDriver converts display text into the bytes a particular board
+// expects on the wire.
+type ScoreboardDriver interface {
+	Name() string
+	// Init returns the frames to send straight after connecting (may be nil)
+	Init() [][]byte
+	// EncodeLine frames text for display on a 1-based line of the board
+	EncodeLine(line int, text string) []byte
+	// EncodeBrightness frames a brightness change (level 0-100)
+	EncodeBrightness(level int) []byte
+	// EncodeClear frames a command blanking every line
+	EncodeClear() []byte
+}
+
+// ScoreboardConfig is the per-board selection made before connecting
+type ScoreboardConfig struct {
+	Protocol   string `json:"protocol"`
+	Address    int    `json:"address"`    // Board address on a shared RS485 bus
+	LineWidth  int    `json:"lineWidth"`  // Characters per line, 0 = driver default
+	Brightness int    `json:"brightness"` // 0-100, applied on connect
+	Layout     string `json:"layout"`     // Scoreboard layout name, empty = single line
+	// Boards that answer each frame with ACK/NAK can be checked for delivery
+	ExpectAck    bool `json:"expectAck"`
+	AckTimeoutMs int  `json:"ackTimeoutMs"`
+}
+
+// ScoreboardProtocolInfo describes a registered driver for the UI
+type ScoreboardProtocolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type scoreboardFactory struct {
+	description string
+	create      func(cfg ScoreboardConfig) ScoreboardDriver
+}
+
+// scoreboardDrivers are generic framings, not implementations of any vendor's
+// protocol. Pick the one matching the framing in your board's manual or its
+// serial interface converter.
+var scoreboardDrivers = map[string]scoreboardFactory{
+	"plain": {
+		description: "Bare text terminated by CR/LF",
+		create:      func(cfg ScoreboardConfig) ScoreboardDriver { return &plainScoreboard{} },
+	},
+	"stx-xor": {
+		description: "STX/ETX packet with 2-digit address, line number and XOR checksum",
+		create:      func(cfg ScoreboardConfig) ScoreboardDriver { return newSTXXorScoreboard(cfg) },
+	},
+	"line-telegram": {
+		description: "Fixed-width CR-terminated line telegram with address and line number",
+		create:      func(cfg ScoreboardConfig) ScoreboardDriver { return newLineTelegramScoreboard(cfg) },
+	},
+	"stx-sum": {
+		description: "STX/ETX packet with 3-digit address, command and modulo-256 checksum",
+		create:      func(cfg ScoreboardConfig) ScoreboardDriver { return newSTXSumScoreboard(cfg) },
+	},
+}
+
+func newScoreboardDriver(cfg ScoreboardConfig) (ScoreboardDriver, error) {
+	protocol := strings.ToLower(strings.TrimSpace(cfg.Protocol))
+	if protocol == "" {
+		protocol = defaultScoreboardProtocol
+	}
+	factory, ok := scoreboardDrivers[protocol]
+	if !ok {
+		return nil, fmt.Errorf("unknown scoreboard protocol '%s'", cfg.Protocol)
+	}
+	return factory.create(cfg), nil
+}
+
+func clampBrightness(level int) int {
+	if level < 0 {
+		return 0
+	}
+	if level > 100 {
+		return 100
+	}
+	return level
+}
+
+// fitToWidth pads or truncates text to exactly width characters
+func fitToWidth(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	if len(text) > width {
+		return text[:width]
+	}
+	return fmt.Sprintf("%*s", width, text) // Right aligned, as marks are read from the right
+}
+
+// --- Plain driver (original behaviour) ---
+type plainScoreboard struct{}
+
+func (d *plainScoreboard) Name() string   { return "plain" }
+func (d *plainScoreboard) Init() [][]byte { return nil }
+
+func (d *plainScoreboard) EncodeLine(line int, text string) []byte {
+	return []byte(text + "\r\n")
+}
+
+func (d *plainScoreboard) EncodeBrightness(level int) []byte { return nil }
+func (d *plainScoreboard) EncodeClear() []byte               { return []byte("\r\n") }
+
+// --- STX/XOR driver ---
+// Packet: STX | addr(2) | 'L' line(1) | text | ETX | XOR checksum as 2 hex chars | CR
+type stxXorScoreboard struct {
+	address   int
+	lineWidth int
+}
+
+func newSTXXorScoreboard(cfg ScoreboardConfig) *stxXorScoreboard {
+	width := cfg.LineWidth
+	if width == 0 {
+		width = 8
+	}
+	return &stxXorScoreboard{address: cfg.Address, lineWidth: width}
+}
+
+func (d *stxXorScoreboard) Name() string   { return "stx-xor" }
+func (d *stxXorScoreboard) Init() [][]byte { return [][]byte{d.EncodeClear()} }
+
+func (d *stxXorScoreboard) frame(body string) []byte {
+	payload := fmt.Sprintf("%02d%s", d.address%100, body)
+	var sum byte
+	for i := 0; i < len(payload); i++ {
+		sum ^= payload[i]
+	}
+	sum ^= asciiETX
+	packet := make([]byte, 0, len(payload)+5)
+	packet = append(packet, asciiSTX)
+	packet = append(packet, payload...)
+	packet = append(packet, asciiETX)
+	packet = append(packet, fmt.Sprintf("%02X", sum)...)
+	return append(packet, asciiCR)
+}
+
+func (d *stxXorScoreboard) EncodeLine(line int, text string) []byte {
+	return d.frame(fmt.Sprintf("L%d%s", line%10, fitToWidth(text, d.lineWidth)))
+}
+
+func (d *stxXorScoreboard) EncodeBrightness(level int) []byte {
+	return d.frame(fmt.Sprintf("B%03d", clampBrightness(level)))
+}
+
+func (d *stxXorScoreboard) EncodeClear() []byte { return d.frame("C") }
+
+// --- Line telegram driver ---
+// Telegram: addr-prefixed fixed-width line, e.g. " 1L2   65.43" followed by CR
+type lineTelegramScoreboard struct {
+	address   int
+	lineWidth int
+}
+
+func newLineTelegramScoreboard(cfg ScoreboardConfig) *lineTelegramScoreboard {
+	width := cfg.LineWidth
+	if width == 0 {
+		width = 12
+	}
+	return &lineTelegramScoreboard{address: cfg.Address, lineWidth: width}
+}
+
+func (d *lineTelegramScoreboard) Name() string   { return "line-telegram" }
+func (d *lineTelegramScoreboard) Init() [][]byte { return [][]byte{d.EncodeClear()} }
+
+func (d *lineTelegramScoreboard) EncodeLine(line int, text string) []byte {
+	return []byte(fmt.Sprintf("%2dL%d%s\r", d.address%100, line%10, fitToWidth(text, d.lineWidth)))
+}
+
+func (d *lineTelegramScoreboard) EncodeBrightness(level int) []byte {
+	// Brightness goes in ten steps
+	return []byte(fmt.Sprintf("%2dH%d\r", d.address%100, clampBrightness(level)/10))
+}
+
+func (d *lineTelegramScoreboard) EncodeClear() []byte {
+	return []byte(fmt.Sprintf("%2dC\r", d.address%100))
+}
+
+// --- STX/sum driver ---
+// Packet: STX | addr(3) | cmd(1) | data | ETX | sum mod 256 as 2 hex chars
+type stxSumScoreboard struct {
+	address   int
+	lineWidth int
+}
+
+func newSTXSumScoreboard(cfg ScoreboardConfig) *stxSumScoreboard {
+	width := cfg.LineWidth
+	if width == 0 {
+		width = 10
+	}
+	return &stxSumScoreboard{address: cfg.Address, lineWidth: width}
+}
+
+func (d *stxSumScoreboard) Name() string   { return "stx-sum" }
+func (d *stxSumScoreboard) Init() [][]byte { return [][]byte{d.EncodeClear()} }
+
+func (d *stxSumScoreboard) frame(cmd byte, data string) []byte {
+	body := fmt.Sprintf("%03d%c%s", d.address%1000, cmd, data)
+	var sum int
+	for i := 0; i < len(body); i++ {
+		sum += int(body[i])
+	}
+	packet := make([]byte, 0, len(body)+4)
+	packet = append(packet, asciiSTX)
+	packet = append(packet, body...)
+	packet = append(packet, asciiETX)
+	return append(packet, fmt.Sprintf("%02X", sum%256)...)
+}
+
+func (d *stxSumScoreboard) EncodeLine(line int, text string) []byte {
+	return d.frame('T', fmt.Sprintf("%02d%s", line%100, fitToWidth(text, d.lineWidth)))
+}
+
+func (d *stxSumScoreboard) EncodeBrightness(level int) []byte {
+	return d.frame('B', fmt.Sprintf("%03d", clampBrightness(level)))
+}
+
+func (d *stxSumScoreboard) EncodeClear() []byte { return d.frame('C', "") }
+
+// --- Wails Bindable Scoreboard Functions ---
+
+// ListScoreboardProtocols returns the available drivers sorted by name
+func (a *App) ListScoreboardProtocols() []ScoreboardProtocolInfo {
+	infos := make([]ScoreboardProtocolInfo, 0, len(scoreboardDrivers))
+	for name, factory := range scoreboardDrivers {
+		infos = append(infos, ScoreboardProtocolInfo{Name: name, Description: factory.description})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// SetScoreboardConfig selects the protocol used for a scoreboard device. It
+// takes effect immediately if the board is connected, otherwise on the next
+// connect. A connected board gets the new driver's start-up frames ahead of
+// anything still queued, and queued or held frames are sent in the new
+// protocol.
+func (a *App) SetScoreboardConfig(board string, cfg ScoreboardConfig) error {
+	if !isScoreboardDevice(board) {
+		return fmt.Errorf("'%s' is not a scoreboard device", board)
+	}
+	driver, err := newScoreboardDriver(cfg)
+	if err != nil {
+		return err
+	}
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	t := a.scoreboardTargetLocked(board)
+	t.config = cfg
+	if dev, ok := a.devices[board]; ok {
+		dev.driver = driver
+		log.Printf("Scoreboard %s protocol changed to %s (address %d)", board, driver.Name(), cfg.Address)
+		if dev.Conn != nil {
+			pending := t.drainQueueLocked()
+			a.queueScoreboardStartLocked(board)
+			for _, frame := range pending {
+				a.pushScoreboardFrameLocked(board, t, frame)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *App) GetScoreboardConfig(board string) ScoreboardConfig {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if t, ok := a.scoreboards[board]; ok {
+		return t.config
+	}
+	return ScoreboardConfig{Protocol: defaultScoreboardProtocol, Layout: defaultScoreboardLayout}
+}
+
+func (a *App) SendToScoreboardLine(board string, line int, value string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.writeScoreboardLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeLine(line, value) },
+		fmt.Sprintf("line %d: '%s'", line, value))
+}
+
+func (a *App) SetScoreboardBrightness(board string, level int) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.writeScoreboardLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeBrightness(level) },
+		fmt.Sprintf("brightness %d", clampBrightness(level)))
+}
+
+func (a *App) ClearScoreboard(board string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.writeScoreboardLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeClear() }, "clear")
+}
+
+// initialiseScoreboardLocked queues the driver's start-up frames, the
+// configured brightness and a test pattern for a freshly connected board,
+// then anything held while it was offline. Caller holds stateMux.
+func (a *App) initialiseScoreboardLocked(board string) {
+	a.queueScoreboardStartLocked(board)
+	a.enqueueScoreboardFrameLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeLine(1, "88:88") }, "'88:88'")
+	a.resendHeldFramesLocked(board)
+}
+
+// queueScoreboardStartLocked queues the driver's start-up frames and the
+// configured brightness. Caller holds stateMux.
+func (a *App) queueScoreboardStartLocked(board string) {
+	dev := a.devices[board]
+	cfg := a.scoreboardTargetLocked(board).config
+	for i := range dev.driver.Init() {
+		a.enqueueScoreboardFrameLocked(board, func(d ScoreboardDriver) []byte {
+			if frames := d.Init(); i < len(frames) {
+				return frames[i]
+			}
+			return nil
+		}, "init")
+	}
+	if cfg.Brightness > 0 && dev.driver.EncodeBrightness(cfg.Brightness) != nil {
+		a.enqueueScoreboardFrameLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeBrightness(cfg.Brightness) },
+			fmt.Sprintf("brightness %d", cfg.Brightness))
+	}
+}
+
+// writeScoreboardLocked encodes with the board's driver and queues the frame
+// for delivery. Caller holds stateMux.
+func (a *App) writeScoreboardLocked(board string, encode func(ScoreboardDriver) []byte, what string) error {
+	if a.demoMode {
+		log.Printf("DEMO: Would send %s to %s", what, board)
+		return nil
+	}
+	scoreboard, ok := a.devices[board]
+	if !ok || scoreboard.Conn == nil {
+		return fmt.Errorf("%s not connected", board)
+	}
+	if scoreboard.driver == nil {
+		scoreboard.driver = &plainScoreboard{}
+	}
+	if encode(scoreboard.driver) == nil {
+		return nil // Not supported by this protocol
+	}
+	a.enqueueScoreboardFrameLocked(board, encode, what)
+	return nil
+}
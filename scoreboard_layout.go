@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultScoreboardLayout = "single"
+	defaultPageDuration     = 4 * time.Second
+)
+
+// ScoreboardPage is one screenful; each entry in Lines is a template for the
+// matching 1-based board line. Supported fields: {bib}, {name}, {attempt},
+// {mark}, {position}, {wind} and {latest} (the most recent mark or wind).
+type ScoreboardPage struct {
+	Lines      []string `json:"lines"`
+	DurationMs int      `json:"durationMs,omitempty"` // Time on screen when cycling pages
+}
+
+type ScoreboardLayout struct {
+	Name  string           `json:"name"`
+	Pages []ScoreboardPage `json:"pages"`
+}
+
+// ScoreboardState is what a station's board currently shows, filled in by
+// SetCurrentAthlete, MeasureThrow and MeasureWind
+type ScoreboardState struct {
+	Bib      string `json:"bib"`
+	Name     string `json:"name"`
+	Attempt  int    `json:"attempt"`
+	Mark     string `json:"mark"`
+	Position int    `json:"position"`
+	Wind     string `json:"wind"`
+	Latest   string `json:"latest"`
+}
+
+var builtinScoreboardLayouts = map[string]ScoreboardLayout{
+	"single": {
+		Name:  "single",
+		Pages: []ScoreboardPage{{Lines: []string{"{latest}"}}},
+	},
+	"two-line": {
+		Name:  "two-line",
+		Pages: []ScoreboardPage{{Lines: []string{"{bib} A{attempt}", "{mark}"}}},
+	},
+	"three-line": {
+		Name:  "three-line",
+		Pages: []ScoreboardPage{{Lines: []string{"{bib} A{attempt}", "{mark}", "P{position} {wind}"}}},
+	},
+	"paged": {
+		Name: "paged",
+		Pages: []ScoreboardPage{
+			{Lines: []string{"{bib}", "{mark}"}, DurationMs: 5000},
+			{Lines: []string{"ATT {attempt}", "POS {position}"}, DurationMs: 3000},
+			{Lines: []string{"WIND", "{wind}"}, DurationMs: 3000},
+		},
+	},
+}
+
+// renderPage substitutes state fields into each line template of a page
+func (s ScoreboardState) renderPage(page ScoreboardPage) []string {
+	attempt, position := "", ""
+	if s.Attempt > 0 {
+		attempt = strconv.Itoa(s.Attempt)
+	}
+	if s.Position > 0 {
+		position = strconv.Itoa(s.Position)
+	}
+	replacer := strings.NewReplacer(
+		"{bib}", s.Bib,
+		"{name}", s.Name,
+		"{attempt}", attempt,
+		"{mark}", s.Mark,
+		"{position}", position,
+		"{wind}", s.Wind,
+		"{latest}", s.Latest,
+	)
+	lines := make([]string, len(page.Lines))
+	for i, tmpl := range page.Lines {
+		lines[i] = strings.TrimSpace(replacer.Replace(tmpl))
+	}
+	return lines
+}
+
+// lookupLayoutLocked resolves a layout name against custom then built-in
+// layouts. Caller holds stateMux.
+func (a *App) lookupLayoutLocked(name string) (ScoreboardLayout, bool) {
+	if name == "" {
+		name = defaultScoreboardLayout
+	}
+	if layout, ok := a.scoreboardLayouts[name]; ok {
+		return layout, true
+	}
+	layout, ok := builtinScoreboardLayouts[name]
+	return layout, ok
+}
+
+// --- Wails Bindable Layout Functions ---
+
+func (a *App) ListScoreboardLayouts() []ScoreboardLayout {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	layouts := make([]ScoreboardLayout, 0, len(builtinScoreboardLayouts)+len(a.scoreboardLayouts))
+	for name, layout := range builtinScoreboardLayouts {
+		if _, overridden := a.scoreboardLayouts[name]; !overridden {
+			layouts = append(layouts, layout)
+		}
+	}
+	for _, layout := range a.scoreboardLayouts {
+		layouts = append(layouts, layout)
+	}
+	sort.Slice(layouts, func(i, j int) bool { return layouts[i].Name < layouts[j].Name })
+	return layouts
+}
+
+// SaveScoreboardLayout adds or replaces a custom layout
+func (a *App) SaveScoreboardLayout(layout ScoreboardLayout) error {
+	if strings.TrimSpace(layout.Name) == "" {
+		return fmt.Errorf("layout name is required")
+	}
+	if len(layout.Pages) == 0 {
+		return fmt.Errorf("layout '%s' has no pages", layout.Name)
+	}
+	for i, page := range layout.Pages {
+		if len(page.Lines) == 0 {
+			return fmt.Errorf("layout '%s' page %d has no lines", layout.Name, i+1)
+		}
+	}
+	a.stateMux.Lock()
+	a.scoreboardLayouts[layout.Name] = layout
+	a.stateMux.Unlock()
+	return nil
+}
+
+// SetScoreboardLayout selects the layout for a scoreboard device and redraws
+// every station showing on it
+func (a *App) SetScoreboardLayout(board string, name string) error {
+	if !isScoreboardDevice(board) {
+		return fmt.Errorf("'%s' is not a scoreboard device", board)
+	}
+	a.stateMux.Lock()
+	if _, ok := a.lookupLayoutLocked(name); !ok {
+		a.stateMux.Unlock()
+		return fmt.Errorf("unknown scoreboard layout '%s'", name)
+	}
+	a.scoreboardTargetLocked(board).config.Layout = name
+	var stations []string
+	for devType, st := range a.stations {
+		if st.scoreboard == board {
+			stations = append(stations, devType)
+		}
+	}
+	a.stateMux.Unlock()
+	for _, devType := range stations {
+		if err := a.refreshScoreboard(devType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetCurrentAthlete sets who is throwing next at a station. Subsequent marks
+// are attributed to the bib and shown on its board with the attempt number.
+func (a *App) SetCurrentAthlete(devType string, bib string, name string, attempt int) error {
+	a.stateMux.Lock()
+	st := a.stationLocked(devType)
+	if entry, ok := a.lookupEntryLocked(devType, bib); ok && name == "" {
+		name = entry.DisplayName()
+	}
+	st.board.Bib = bib
+	st.board.Name = name
+	st.board.Attempt = attempt
+	st.board.Mark = ""
+	st.board.Position = a.athletePositionLocked(devType, bib)
+	a.stateMux.Unlock()
+	return a.refreshScoreboard(devType)
+}
+
+func (a *App) GetScoreboardState(devType string) ScoreboardState {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if st, ok := a.findStationLocked(devType); ok {
+		return st.board
+	}
+	return ScoreboardState{}
+}
+
+// --- Layout rendering ---
+
+// athletePositionLocked ranks the athlete's best mark in the station's
+// session. Returns 0 when the athlete has no mark. Caller holds stateMux.
+func (a *App) athletePositionLocked(devType, bib string) int {
+	st, ok := a.findStationLocked(devType)
+	if bib == "" || !ok || st.session == nil {
+		return 0
+	}
+	for _, s := range rankCoordinates(st.session.Coordinates) {
+		if s.AthleteID == bib {
+			return s.Position
+		}
+	}
+	return 0
+}
+
+// recordScoreboardMark updates a station's board state after a measured throw
+func (a *App) recordScoreboardMark(devType string, mark string) {
+	a.stateMux.Lock()
+	st := a.stationLocked(devType)
+	st.board.Mark = mark
+	st.board.Latest = mark
+	st.board.Position = a.athletePositionLocked(devType, st.board.Bib)
+	a.stateMux.Unlock()
+	if err := a.refreshScoreboard(devType); err != nil {
+		log.Printf("Scoreboard update for %s failed: %v", devType, err)
+	}
+}
+
+// recordScoreboardWind updates every station's board state after a wind
+// measurement, since one gauge serves the whole field
+func (a *App) recordScoreboardWind(wind string) {
+	a.stateMux.Lock()
+	stations := make([]string, 0, len(a.stations))
+	for devType, st := range a.stations {
+		st.board.Wind = wind
+		st.board.Latest = wind
+		stations = append(stations, devType)
+	}
+	a.stateMux.Unlock()
+	for _, devType := range stations {
+		if err := a.refreshScoreboard(devType); err != nil {
+			log.Printf("Scoreboard update for %s failed: %v", devType, err)
+		}
+	}
+}
+
+// refreshScoreboard draws the first page of the station's layout on its
+// board and, for multi-page layouts, starts cycling through the rest
+func (a *App) refreshScoreboard(devType string) error {
+	a.stateMux.Lock()
+	st, ok := a.findStationLocked(devType)
+	if !ok {
+		a.stateMux.Unlock()
+		return nil
+	}
+	if st.stopPageCycle != nil {
+		st.stopPageCycle()
+		st.stopPageCycle = nil
+	}
+	board := st.scoreboard
+	if _, connected := a.devices[board]; board == "" || (!connected && !a.demoMode) {
+		a.stateMux.Unlock()
+		return nil
+	}
+	layoutName := a.scoreboardTargetLocked(board).config.Layout
+	layout, ok := a.lookupLayoutLocked(layoutName)
+	if !ok {
+		a.stateMux.Unlock()
+		return fmt.Errorf("unknown scoreboard layout '%s'", layoutName)
+	}
+	state := st.board
+	err := a.drawScoreboardPageLocked(board, state.renderPage(layout.Pages[0]))
+	if err == nil && len(layout.Pages) > 1 {
+		ctx, cancel := context.WithCancel(context.Background())
+		st.stopPageCycle = cancel
+		go a.cycleScoreboardPages(ctx, board, layout, state)
+	}
+	a.stateMux.Unlock()
+	return err
+}
+
+func (a *App) cycleScoreboardPages(ctx context.Context, board string, layout ScoreboardLayout, state ScoreboardState) {
+	page := 0
+	for {
+		duration := defaultPageDuration
+		if ms := layout.Pages[page].DurationMs; ms > 0 {
+			duration = time.Duration(ms) * time.Millisecond
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(duration):
+		}
+		page = (page + 1) % len(layout.Pages)
+		a.stateMux.Lock()
+		if ctx.Err() == nil {
+			if err := a.drawScoreboardPageLocked(board, state.renderPage(layout.Pages[page])); err != nil {
+				log.Printf("Scoreboard page cycle stopped: %v", err)
+				a.stateMux.Unlock()
+				return
+			}
+		}
+		a.stateMux.Unlock()
+	}
+}
+
+// drawScoreboardPageLocked writes each rendered line to its board line.
+// Caller holds stateMux.
+func (a *App) drawScoreboardPageLocked(board string, lines []string) error {
+	for i, text := range lines {
+		line := i + 1
+		if err := a.writeScoreboardLocked(board, func(d ScoreboardDriver) []byte { return d.EncodeLine(line, text) },
+			fmt.Sprintf("line %d: '%s'", line, text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
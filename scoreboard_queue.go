@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	asciiACK = 0x06
+	asciiNAK = 0x15
+)
+
+const (
+	scoreboardQueueSize     = 64
+	scoreboardMaxAttempts   = 4
+	scoreboardRetryBase     = 250 * time.Millisecond
+	defaultScoreboardAckTTL = 500 * time.Millisecond
+	// Undelivered frames kept while the board is offline, enough to redraw
+	// every line of a layout once it reconnects
+	scoreboardHeldFrames = 8
+)
+
+var (
+	errScoreboardNAK     = errors.New("scoreboard rejected frame (NAK)")
+	errScoreboardOffline = errors.New("scoreboard offline")
+)
+
+// scoreboardFrame is one packet waiting for delivery. It is encoded with the
+// board's driver when it is written, so frames queued or held before a
+// protocol change go out in the new protocol.
+type scoreboardFrame struct {
+	encode      func(ScoreboardDriver) []byte // nil result: not supported by the driver
+	description string
+	queuedAt    time.Time
+}
+
+// scoreboardTarget is one scoreboard device's protocol settings, delivery
+// queue and health, keyed by device type like devices
+type scoreboardTarget struct {
+	config ScoreboardConfig
+	queue  chan scoreboardFrame
+	held   []scoreboardFrame  // Latest frames that could not be delivered, resent on reconnect
+	stop   context.CancelFunc // Stops the delivery goroutine, nil while none runs
+	status ScoreboardStatus
+}
+
+// ScoreboardStatus reports whether the board is actually receiving what we send
+type ScoreboardStatus struct {
+	Device              string    `json:"device"`
+	Connected           bool      `json:"connected"`
+	Protocol            string    `json:"protocol"`
+	Address             string    `json:"address"`
+	QueueLength         int       `json:"queueLength"`
+	Held                int       `json:"held"` // Frames waiting for the board to reconnect
+	LastSent            string    `json:"lastSent"`
+	LastSentAt          time.Time `json:"lastSentAt"`
+	LastAcknowledged    bool      `json:"lastAcknowledged"` // Only meaningful when ExpectAck is set
+	LastError           string    `json:"lastError,omitempty"`
+	LastErrorAt         time.Time `json:"lastErrorAt"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	Delivered           int       `json:"delivered"`
+	Failed              int       `json:"failed"`
+	Dropped             int       `json:"dropped"`
+	Reconnects          int       `json:"reconnects"`
+	State               string    `json:"state"`
+}
+
+// scoreboardTargetLocked returns the settings and queue for a scoreboard
+// device. Caller holds stateMux.
+func (a *App) scoreboardTargetLocked(board string) *scoreboardTarget {
+	t, ok := a.scoreboards[board]
+	if !ok {
+		t = &scoreboardTarget{
+			config: ScoreboardConfig{Protocol: defaultScoreboardProtocol, Layout: defaultScoreboardLayout},
+			queue:  make(chan scoreboardFrame, scoreboardQueueSize),
+		}
+		a.scoreboards[board] = t
+	}
+	return t
+}
+
+// stopScoreboardLocked ends a board's delivery goroutine and discards its
+// queued and held frames, keeping its settings. It is used when the board is
+// disconnected on purpose or the app shuts down. Caller holds stateMux.
+func (a *App) stopScoreboardLocked(board string) {
+	t, ok := a.scoreboards[board]
+	if !ok {
+		return
+	}
+	if t.stop != nil {
+		t.stop()
+		t.stop = nil
+	}
+	t.drainQueueLocked()
+	t.held = nil
+}
+
+// enqueueScoreboardFrameLocked queues a frame for ordered delivery. If the
+// board has fallen far behind the oldest frame is dropped, since only the
+// latest state matters to the display. Caller holds stateMux.
+func (a *App) enqueueScoreboardFrameLocked(board string, encode func(ScoreboardDriver) []byte, description string) {
+	t := a.scoreboardTargetLocked(board)
+	frame := scoreboardFrame{encode: encode, description: description, queuedAt: time.Now()}
+	a.pushScoreboardFrameLocked(board, t, frame)
+}
+
+func (a *App) pushScoreboardFrameLocked(board string, t *scoreboardTarget, frame scoreboardFrame) {
+	if t.stop == nil {
+		ctx, stop := context.WithCancel(context.Background())
+		t.stop = stop
+		go a.runScoreboardQueue(ctx, board, t)
+	}
+	for {
+		select {
+		case t.queue <- frame:
+			return
+		default:
+		}
+		select {
+		case old := <-t.queue:
+			t.status.Dropped++
+			log.Printf("Scoreboard %s queue full, dropped %s", board, old.description)
+		default:
+		}
+	}
+}
+
+// runScoreboardQueue delivers queued frames one at a time until ctx is
+// cancelled by stopScoreboardLocked
+func (a *App) runScoreboardQueue(ctx context.Context, board string, t *scoreboardTarget) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame := <-t.queue:
+			a.deliverScoreboardFrame(ctx, board, t, frame)
+		}
+	}
+}
+
+// holdFrameLocked keeps a frame that could not be delivered because
+// the board is offline, dropping the oldest beyond scoreboardHeldFrames.
+// Caller holds stateMux.
+func (t *scoreboardTarget) holdFrameLocked(frame scoreboardFrame) {
+	t.held = append(t.held, frame)
+	if excess := len(t.held) - scoreboardHeldFrames; excess > 0 {
+		t.status.Dropped += excess
+		t.held = t.held[excess:]
+	}
+}
+
+// drainQueueLocked takes the frames still waiting in the queue.
+// Caller holds stateMux.
+func (t *scoreboardTarget) drainQueueLocked() []scoreboardFrame {
+	var frames []scoreboardFrame
+	for {
+		select {
+		case frame := <-t.queue:
+			frames = append(frames, frame)
+		default:
+			return frames
+		}
+	}
+}
+
+// resendHeldFramesLocked queues the frames held while the board was offline.
+// Caller holds stateMux.
+func (a *App) resendHeldFramesLocked(board string) {
+	t := a.scoreboardTargetLocked(board)
+	held := t.held
+	t.held = nil
+	for _, frame := range held {
+		a.pushScoreboardFrameLocked(board, t, frame)
+	}
+	if len(held) > 0 {
+		log.Printf("Scoreboard %s back online, resending %d held frames", board, len(held))
+	}
+}
+
+// deliverScoreboardFrame writes a frame, retrying with exponential backoff
+// and reconnecting the board if the write itself failed. A frame that cannot
+// be delivered because the board is offline is held until it reconnects, as
+// the supervisor's reconnect usually outlasts the retries.
+func (a *App) deliverScoreboardFrame(ctx context.Context, board string, t *scoreboardTarget, frame scoreboardFrame) {
+	backoff := scoreboardRetryBase
+	var err error
+	for attempt := 1; attempt <= scoreboardMaxAttempts; attempt++ {
+		err = a.writeScoreboardFrame(ctx, board, t, frame)
+		a.stateMux.Lock()
+		if ctx.Err() != nil {
+			a.stateMux.Unlock()
+			return // Stopped; the frame is discarded with the rest
+		}
+		if err == nil {
+			t.status.LastSent = frame.description
+			t.status.LastSentAt = time.Now()
+			t.status.ConsecutiveFailures = 0
+			t.status.Delivered++
+			a.stateMux.Unlock()
+			return
+		}
+		t.status.LastError = err.Error()
+		t.status.LastErrorAt = time.Now()
+		t.status.ConsecutiveFailures++
+		if errors.Is(err, errScoreboardOffline) {
+			t.holdFrameLocked(frame)
+			a.stateMux.Unlock()
+			log.Printf("Scoreboard %s offline, holding %s until it reconnects", board, frame.description)
+			return
+		}
+		a.stateMux.Unlock()
+
+		log.Printf("Scoreboard %s delivery of %s failed (attempt %d/%d): %v",
+			board, frame.description, attempt, scoreboardMaxAttempts, err)
+		var writeErr *scoreboardWriteError
+		if errors.As(err, &writeErr) {
+			a.reportDeviceFailure(board, writeErr.err)
+		}
+		if attempt == scoreboardMaxAttempts {
+			break
+		}
+		if sleepCtx(ctx, backoff) != nil {
+			return
+		}
+		backoff *= 2
+	}
+	a.stateMux.Lock()
+	var writeErr *scoreboardWriteError
+	if errors.As(err, &writeErr) {
+		t.holdFrameLocked(frame)
+	} else {
+		t.status.Failed++
+	}
+	a.stateMux.Unlock()
+}
+
+type scoreboardWriteError struct{ err error }
+
+func (e *scoreboardWriteError) Error() string { return fmt.Sprintf("write failed: %v", e.err) }
+func (e *scoreboardWriteError) Unwrap() error { return e.err }
+
+// writeScoreboardFrame sends one frame and, if configured, waits for the
+// board's ACK/NAK. The connection is used outside stateMux so a slow board
+// does not stall measurements.
+func (a *App) writeScoreboardFrame(ctx context.Context, board string, t *scoreboardTarget, frame scoreboardFrame) error {
+	a.stateMux.Lock()
+	dev, ok := a.devices[board]
+	if !ok || dev.Conn == nil {
+		a.stateMux.Unlock()
+		return fmt.Errorf("%s not connected: %w", board, errScoreboardOffline)
+	}
+	if dev.state != DeviceStateConnected {
+		a.stateMux.Unlock()
+		return fmt.Errorf("%s %s: %w", board, dev.state, errScoreboardOffline)
+	}
+	if dev.driver == nil {
+		dev.driver = &plainScoreboard{}
+	}
+	data := frame.encode(dev.driver)
+	dio := dev.io
+	expectAck := t.config.ExpectAck
+	ackTimeout := time.Duration(t.config.AckTimeoutMs) * time.Millisecond
+	a.stateMux.Unlock()
+	if ackTimeout <= 0 {
+		ackTimeout = defaultScoreboardAckTTL
+	}
+	if data == nil {
+		log.Printf("Scoreboard %s protocol does not support %s, skipped", board, frame.description)
+		return nil
+	}
+
+	var acknowledged bool
+	err := dio.do(ctx, func(dio *deviceIO) error {
+		if expectAck {
+			dio.flushInput()
+		}
+		if err := dio.write(data); err != nil {
+			return &scoreboardWriteError{err: err}
+		}
+		if !expectAck {
+			return nil
+		}
+		deadline := time.Now().Add(ackTimeout)
+		for time.Now().Before(deadline) {
+			b, err := dio.readByte(time.Until(deadline))
+			if err != nil {
+				if isConnectionFailure(err) {
+					return &scoreboardWriteError{err: err}
+				}
+				break
+			}
+			switch b {
+			case asciiACK:
+				acknowledged = true
+				return nil
+			case asciiNAK:
+				return errScoreboardNAK
+			}
+		}
+		return fmt.Errorf("no acknowledgement within %v", ackTimeout)
+	})
+	if errors.Is(err, errDeviceClosed) {
+		err = &scoreboardWriteError{err: err}
+	}
+	if expectAck {
+		a.stateMux.Lock()
+		t.status.LastAcknowledged = acknowledged
+		a.stateMux.Unlock()
+	}
+	return err
+}
+
+// scoreboardStatusLocked combines delivery health with the device state.
+// Caller holds stateMux.
+func (a *App) scoreboardStatusLocked(board string) ScoreboardStatus {
+	var status ScoreboardStatus
+	if t, ok := a.scoreboards[board]; ok {
+		status = t.status
+		status.QueueLength = len(t.queue)
+		status.Held = len(t.held)
+	}
+	status.Device = board
+	if dev, ok := a.devices[board]; ok && dev.Conn != nil {
+		status.Connected = dev.state == DeviceStateConnected
+		status.State = dev.state
+		status.Address = dev.Address
+		status.Reconnects = dev.reconnects
+		if dev.driver != nil {
+			status.Protocol = dev.driver.Name()
+		}
+	}
+	return status
+}
+
+// GetScoreboardStatus reports delivery health so the official can confirm
+// the board shows the right mark
+func (a *App) GetScoreboardStatus(board string) ScoreboardStatus {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	return a.scoreboardStatusLocked(board)
+}
+
+// GetScoreboardStatuses reports every scoreboard that is configured or connected
+func (a *App) GetScoreboardStatuses() []ScoreboardStatus {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	boards := make(map[string]bool)
+	for board := range a.scoreboards {
+		boards[board] = true
+	}
+	for devType := range a.devices {
+		if isScoreboardDevice(devType) {
+			boards[devType] = true
+		}
+	}
+	statuses := make([]ScoreboardStatus, 0, len(boards))
+	for board := range boards {
+		statuses = append(statuses, a.scoreboardStatusLocked(board))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Device < statuses[j].Device })
+	return statuses
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go.bug.st/serial"
+)
+
+const (
+	serialPresetsFile = "serial_presets.json"
+	ctsWaitTimeout    = 2 * time.Second
+	ctsPollInterval   = 10 * time.Millisecond
+)
+
+// SerialSettings is the line configuration for a serial device
+type SerialSettings struct {
+	BaudRate    int    `json:"baudRate"`
+	DataBits    int    `json:"dataBits"`
+	Parity      string `json:"parity"`      // none, odd, even, mark, space
+	StopBits    string `json:"stopBits"`    // 1, 1.5, 2
+	FlowControl string `json:"flowControl"` // none, rtscts
+	DTR         bool   `json:"dtr"`
+	RTS         bool   `json:"rts"`
+}
+
+// SerialPreset is a named set of serial settings for an instrument model
+type SerialPreset struct {
+	Name       string         `json:"name"`
+	Model      string         `json:"model"`
+	DeviceType string         `json:"deviceType"` // edm, wind, scoreboard
+	Settings   SerialSettings `json:"settings"`
+	BuiltIn    bool           `json:"builtIn"`
+}
+
+// defaultSerialSettings matches the original hard-coded 9600 8-N-1
+func defaultSerialSettings() SerialSettings {
+	return SerialSettings{BaudRate: 9600, DataBits: 8, Parity: "none", StopBits: "1", FlowControl: "none", DTR: true, RTS: true}
+}
+
+var builtinSerialPresets = []SerialPreset{
+	{Name: "EDM 9600 8-N-1", Model: "Generic total station", DeviceType: "edm", Settings: defaultSerialSettings()},
+	{Name: "Wind 4800 8-N-1", Model: "Generic wind gauge", DeviceType: "wind",
+		Settings: SerialSettings{BaudRate: 4800, DataBits: 8, Parity: "none", StopBits: "1", FlowControl: "none", DTR: true, RTS: true}},
+	{Name: "Wind 19200 8-N-1", Model: "Generic wind gauge", DeviceType: "wind",
+		Settings: SerialSettings{BaudRate: 19200, DataBits: 8, Parity: "none", StopBits: "1", FlowControl: "none", DTR: true, RTS: true}},
+	{Name: "Scoreboard 9600 7-E-1", Model: "Generic scoreboard", DeviceType: "scoreboard",
+		Settings: SerialSettings{BaudRate: 9600, DataBits: 7, Parity: "even", StopBits: "1", FlowControl: "none", DTR: true, RTS: true}},
+}
+
+// normalise fills unset fields with defaults and validates the rest
+func (s SerialSettings) normalise() (SerialSettings, error) {
+	def := defaultSerialSettings()
+	if s.BaudRate == 0 {
+		s.BaudRate = def.BaudRate
+	}
+	if s.DataBits == 0 {
+		s.DataBits = def.DataBits
+	}
+	if s.DataBits < 5 || s.DataBits > 8 {
+		return s, fmt.Errorf("data bits must be 5-8, got %d", s.DataBits)
+	}
+	s.Parity = strings.ToLower(s.Parity)
+	if s.Parity == "" {
+		s.Parity = def.Parity
+	}
+	if s.StopBits == "" {
+		s.StopBits = def.StopBits
+	}
+	s.FlowControl = strings.ToLower(s.FlowControl)
+	if s.FlowControl == "" {
+		s.FlowControl = def.FlowControl
+	}
+	if _, err := s.mode(); err != nil {
+		return s, err
+	}
+	if s.FlowControl != "none" && s.FlowControl != "rtscts" {
+		return s, fmt.Errorf("unsupported flow control '%s'", s.FlowControl)
+	}
+	return s, nil
+}
+
+// mode converts the settings into the serial library's representation
+func (s SerialSettings) mode() (*serial.Mode, error) {
+	mode := &serial.Mode{
+		BaudRate:          s.BaudRate,
+		DataBits:          s.DataBits,
+		InitialStatusBits: &serial.ModemOutputBits{DTR: s.DTR, RTS: s.RTS},
+	}
+	switch s.Parity {
+	case "none", "n":
+		mode.Parity = serial.NoParity
+	case "odd", "o":
+		mode.Parity = serial.OddParity
+	case "even", "e":
+		mode.Parity = serial.EvenParity
+	case "mark", "m":
+		mode.Parity = serial.MarkParity
+	case "space", "s":
+		mode.Parity = serial.SpaceParity
+	default:
+		return nil, fmt.Errorf("unsupported parity '%s'", s.Parity)
+	}
+	switch s.StopBits {
+	case "1":
+		mode.StopBits = serial.OneStopBit
+	case "1.5":
+		mode.StopBits = serial.OnePointFiveStopBits
+	case "2":
+		mode.StopBits = serial.TwoStopBits
+	default:
+		return nil, fmt.Errorf("unsupported stop bits '%s'", s.StopBits)
+	}
+	return mode, nil
+}
+
+// String gives the conventional short form, e.g. "9600 7-E-1"
+func (s SerialSettings) String() string {
+	parity := "N"
+	if s.Parity != "" {
+		parity = strings.ToUpper(s.Parity[:1])
+	}
+	return fmt.Sprintf("%d %d-%s-%s", s.BaudRate, s.DataBits, parity, s.StopBits)
+}
+
+// openSerialPort opens a port with the given settings. The serial library
+// does not drive RTS/CTS itself, so "rtscts" wraps the port to hold writes
+// until the device asserts CTS.
+func openSerialPort(portName string, settings SerialSettings) (serial.Port, error) {
+	mode, err := settings.mode()
+	if err != nil {
+		return nil, err
+	}
+	port, err := serial.Open(portName, mode)
+	if err != nil {
+		return nil, err
+	}
+	if settings.FlowControl == "rtscts" {
+		return &ctsGatedPort{Port: port}, nil
+	}
+	return port, nil
+}
+
+// ctsGatedPort implements RTS/CTS handshaking in software for writes
+type ctsGatedPort struct {
+	serial.Port
+}
+
+func (p *ctsGatedPort) Write(b []byte) (int, error) {
+	deadline := time.Now().Add(ctsWaitTimeout)
+	for {
+		bits, err := p.GetModemStatusBits()
+		if err != nil {
+			return 0, err
+		}
+		if bits.CTS {
+			break
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("timed out waiting for CTS")
+		}
+		time.Sleep(ctsPollInterval)
+	}
+	return p.Port.Write(b)
+}
+
+// --- Wails Bindable Serial Functions ---
+
+// ConnectSerialDeviceWithSettings connects with explicit line settings
+func (a *App) ConnectSerialDeviceWithSettings(devType, portName string, settings SerialSettings) (string, error) {
+	settings, err := settings.normalise()
+	if err != nil {
+		return "", err
+	}
+	return a.connectSerial(devType, portName, settings)
+}
+
+// ConnectSerialDeviceWithPreset connects using a named preset
+func (a *App) ConnectSerialDeviceWithPreset(devType, portName, presetName string) (string, error) {
+	a.stateMux.Lock()
+	preset, ok := a.findSerialPresetLocked(presetName)
+	a.stateMux.Unlock()
+	if !ok {
+		return "", fmt.Errorf("unknown serial preset '%s'", presetName)
+	}
+	return a.ConnectSerialDeviceWithSettings(devType, portName, preset.Settings)
+}
+
+func (a *App) ListSerialPresets() []SerialPreset {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	presets := make([]SerialPreset, 0, len(builtinSerialPresets)+len(a.serialPresets))
+	for _, p := range builtinSerialPresets {
+		p.BuiltIn = true
+		presets = append(presets, p)
+	}
+	presets = append(presets, a.serialPresets...)
+	sort.SliceStable(presets, func(i, j int) bool { return presets[i].DeviceType < presets[j].DeviceType })
+	return presets
+}
+
+// SaveSerialPreset adds or replaces a user preset and persists it
+func (a *App) SaveSerialPreset(preset SerialPreset) error {
+	if strings.TrimSpace(preset.Name) == "" {
+		return fmt.Errorf("preset name is required")
+	}
+	settings, err := preset.Settings.normalise()
+	if err != nil {
+		return err
+	}
+	preset.Settings = settings
+	preset.BuiltIn = false
+
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	for _, p := range builtinSerialPresets {
+		if p.Name == preset.Name {
+			return fmt.Errorf("'%s' is a built-in preset", preset.Name)
+		}
+	}
+	replaced := false
+	for i := range a.serialPresets {
+		if a.serialPresets[i].Name == preset.Name {
+			a.serialPresets[i] = preset
+			replaced = true
+		}
+	}
+	if !replaced {
+		a.serialPresets = append(a.serialPresets, preset)
+	}
+	return saveJSONConfig(serialPresetsFile, a.serialPresets)
+}
+
+func (a *App) DeleteSerialPreset(name string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	for i := range a.serialPresets {
+		if a.serialPresets[i].Name == name {
+			a.serialPresets = append(a.serialPresets[:i], a.serialPresets[i+1:]...)
+			return saveJSONConfig(serialPresetsFile, a.serialPresets)
+		}
+	}
+	return fmt.Errorf("no user preset named '%s'", name)
+}
+
+// findSerialPresetLocked looks in user presets then built-ins. Caller holds stateMux.
+func (a *App) findSerialPresetLocked(name string) (SerialPreset, bool) {
+	for _, p := range a.serialPresets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	for _, p := range builtinSerialPresets {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return SerialPreset{}, false
+}
+
+func loadSerialPresets() []SerialPreset {
+	var presets []SerialPreset
+	if err := loadJSONConfig(serialPresetsFile, &presets); err != nil {
+		log.Printf("Could not load serial presets: %v", err)
+	}
+	return presets
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Finished sessions are kept in an archive on disk, keyed by session ID, so
+// they can be reviewed, renamed, combined or removed later. Deleting or
+// merging only changes the archive: the throws stay in the overall results,
+// and a central sync instance keeps the copies it already received. Only
+// renames are forwarded, as a new snapshot of the session.
+
+const sessionArchiveFile = "sessions.json"
+
+func loadSessionArchive() map[string]*ThrowSession {
+	sessions := make(map[string]*ThrowSession)
+	if err := loadJSONConfig(sessionArchiveFile, &sessions); err != nil {
+		log.Printf("Could not load session archive: %v", err)
+	}
+	return sessions
+}
+
+// cloneSession copies a session deeply, so callers outside stateMux never
+// share its throws, roster or statistics with the live one
+func cloneSession(session *ThrowSession) *ThrowSession {
+	c := *session
+	c.Roster = slices.Clone(session.Roster)
+	if session.EndTime != nil {
+		end := *session.EndTime
+		c.EndTime = &end
+	}
+	if session.Coordinates != nil {
+		c.Coordinates = make([]ThrowCoordinate, len(session.Coordinates))
+		for i, coord := range session.Coordinates {
+			coord.Tags = slices.Clone(coord.Tags)
+			c.Coordinates[i] = coord
+		}
+	}
+	c.Statistics = cloneStatistics(session.Statistics)
+	return &c
+}
+
+func cloneStatistics(stats *SessionStatistics) *SessionStatistics {
+	if stats == nil {
+		return nil
+	}
+	c := *stats
+	clone := func(m map[string]*SessionStatistics) map[string]*SessionStatistics {
+		if m == nil {
+			return nil
+		}
+		out := make(map[string]*SessionStatistics, len(m))
+		for k, v := range m {
+			out[k] = cloneStatistics(v)
+		}
+		return out
+	}
+	c.ByAthlete, c.ByImplement = clone(stats.ByAthlete), clone(stats.ByImplement)
+	return &c
+}
+
+// archiveSessionLocked stores a finished session. Caller holds stateMux.
+func (a *App) archiveSessionLocked(session *ThrowSession) {
+	a.sessions[session.SessionID] = session
+	if err := saveJSONConfig(sessionArchiveFile, a.sessions); err != nil {
+		log.Printf("Could not save session archive: %v", err)
+	}
+}
+
+// sessionInUseLocked reports whether the ID belongs to an archived session
+// or one still running on a station. Caller holds stateMux.
+func (a *App) sessionInUseLocked(sessionID string) bool {
+	if _, ok := a.sessions[sessionID]; ok {
+		return true
+	}
+	for _, st := range a.stations {
+		if st.session != nil && st.session.SessionID == sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Wails Bindable Session Archive Functions ---
+
+// ListSessions returns the archived sessions, newest first, without their
+// throws
+func (a *App) ListSessions() []ThrowSession {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	sessions := make([]ThrowSession, 0, len(a.sessions))
+	for _, session := range a.sessions {
+		summary := *session
+		summary.Coordinates = nil
+		sessions = append(sessions, summary)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime.After(sessions[j].StartTime) })
+	return sessions
+}
+
+// GetSession returns an archived or running session with its throws
+func (a *App) GetSession(sessionID string) (*ThrowSession, error) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if session, ok := a.sessions[sessionID]; ok {
+		return cloneSession(session), nil
+	}
+	for _, st := range a.stations {
+		if st.session != nil && st.session.SessionID == sessionID {
+			return cloneSession(st.session), nil
+		}
+	}
+	return nil, fmt.Errorf("session '%s' not found", sessionID)
+}
+
+// RenameSession sets the name shown for an archived session. The session
+// ID, which throws and synced copies refer to, does not change.
+func (a *App) RenameSession(sessionID string, name string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	session, ok := a.sessions[sessionID]
+	if !ok {
+		return fmt.Errorf("session '%s' not found", sessionID)
+	}
+	session.Name = name
+	a.queueSessionSync(session)
+	return saveJSONConfig(sessionArchiveFile, a.sessions)
+}
+
+// DeleteSession removes a session from the archive. Its throws stay in the
+// results without a session. The delete is not forwarded to a central sync
+// instance.
+func (a *App) DeleteSession(sessionID string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if _, ok := a.sessions[sessionID]; !ok {
+		return fmt.Errorf("session '%s' not found", sessionID)
+	}
+	sessions := maps.Clone(a.sessions)
+	delete(sessions, sessionID)
+	if err := saveJSONConfig(sessionArchiveFile, sessions); err != nil {
+		return err
+	}
+	a.sessions = sessions
+	for i := range a.throwCoordinates {
+		if a.throwCoordinates[i].SessionID == sessionID {
+			a.throwCoordinates[i].SessionID = ""
+		}
+	}
+	log.Printf("Deleted session %s from the archive", sessionID)
+	return nil
+}
+
+// MergeSessions combines archived sessions of the same circle type and event
+// into one new session with recomputed statistics, replacing the originals.
+// An empty newID generates one.
+func (a *App) MergeSessions(sessionIDs []string, newID string) (*ThrowSession, error) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if len(sessionIDs) < 2 {
+		return nil, fmt.Errorf("select at least two sessions to merge")
+	}
+	if newID == "" {
+		newID = newRecordID()
+	}
+	if a.sessionInUseLocked(newID) && !slices.Contains(sessionIDs, newID) {
+		return nil, fmt.Errorf("session '%s' already exists", newID)
+	}
+
+	var parts []*ThrowSession
+	for _, id := range sessionIDs {
+		session, ok := a.sessions[id]
+		if !ok {
+			return nil, fmt.Errorf("session '%s' not found", id)
+		}
+		if len(parts) > 0 && session.CircleType != parts[0].CircleType {
+			return nil, fmt.Errorf("cannot merge %s session '%s' with %s session '%s'",
+				session.CircleType, id, parts[0].CircleType, parts[0].SessionID)
+		}
+		if len(parts) > 0 && session.Event != parts[0].Event {
+			return nil, fmt.Errorf("cannot merge session '%s' for event '%s' with session '%s' for event '%s'",
+				id, session.Event, parts[0].SessionID, parts[0].Event)
+		}
+		parts = append(parts, session)
+	}
+
+	merged := &ThrowSession{
+		SessionID:   newID,
+		Name:        parts[0].Name,
+		Station:     parts[0].Station,
+		Origin:      a.sync.origin(),
+		CircleType:  parts[0].CircleType,
+		Event:       parts[0].Event,
+		Mode:        parts[0].Mode,
+		StartTime:   parts[0].StartTime,
+		Coordinates: make([]ThrowCoordinate, 0),
+	}
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		if part.Station != merged.Station {
+			merged.Station = ""
+		}
+		if part.Mode != merged.Mode {
+			merged.Mode = ""
+		}
+		if part.StartTime.Before(merged.StartTime) {
+			merged.StartTime = part.StartTime
+		}
+		if part.EndTime != nil && (merged.EndTime == nil || part.EndTime.After(*merged.EndTime)) {
+			end := *part.EndTime
+			merged.EndTime = &end
+		}
+		for _, bib := range part.Roster {
+			if !slices.Contains(merged.Roster, bib) {
+				merged.Roster = append(merged.Roster, bib)
+			}
+		}
+		for _, coord := range part.Coordinates {
+			if coord.ID != "" && seen[coord.ID] {
+				continue
+			}
+			seen[coord.ID] = true
+			coord.SessionID = newID
+			merged.Coordinates = append(merged.Coordinates, coord)
+		}
+	}
+	if merged.EndTime == nil {
+		now := time.Now().UTC()
+		merged.EndTime = &now
+	}
+	sort.SliceStable(merged.Coordinates, func(i, j int) bool {
+		return merged.Coordinates[i].Timestamp.Before(merged.Coordinates[j].Timestamp)
+	})
+	updateSessionStatistics(merged)
+
+	// Save the new archive before changing anything, so a failed write
+	// leaves the originals in place
+	sessions := maps.Clone(a.sessions)
+	for _, id := range sessionIDs {
+		delete(sessions, id)
+	}
+	sessions[newID] = merged
+	if err := saveJSONConfig(sessionArchiveFile, sessions); err != nil {
+		return nil, err
+	}
+	a.sessions = sessions
+	for i := range a.throwCoordinates {
+		if slices.Contains(sessionIDs, a.throwCoordinates[i].SessionID) {
+			a.throwCoordinates[i].SessionID = newID
+		}
+	}
+	a.queueSessionSync(merged)
+	log.Printf("Merged %d sessions into %s: %d throws", len(parts), newID, len(merged.Coordinates))
+	return cloneSession(merged), nil
+}
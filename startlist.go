@@ -0,0 +1,514 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const startListsFile = "start_lists.json"
+
+// StartListEntry is one athlete entered in an event
+type StartListEntry struct {
+	Bib         string `json:"bib"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	Affiliation string `json:"affiliation"` // Club or team
+	Order       int    `json:"order"`       // Throwing order, 0 if not given
+}
+
+// DisplayName is how the athlete is shown on the board and in results
+func (e StartListEntry) DisplayName() string {
+	return strings.TrimSpace(e.FirstName + " " + e.LastName)
+}
+
+// StartList is the entries for one event, as imported
+type StartList struct {
+	Event       string           `json:"event"`
+	Source      string           `json:"source"`                // csv, lynx or json
+	EventNumber string           `json:"eventNumber,omitempty"` // Meet-manager numbering from Lynx files, used on export
+	Round       string           `json:"round,omitempty"`
+	Flight      string           `json:"flight,omitempty"`
+	ImportedAt  time.Time        `json:"importedAt"`
+	Entries     []StartListEntry `json:"entries"`
+}
+
+// CSVColumnMapping picks the columns holding each field. Columns are 0-based;
+// -1 means not present, as does leaving a field out of the JSON. Name is used
+// when first and last names share a column.
+type CSVColumnMapping struct {
+	Bib         int    `json:"bib"`
+	FirstName   int    `json:"firstName"`
+	LastName    int    `json:"lastName"`
+	Name        int    `json:"name"`
+	Affiliation int    `json:"affiliation"`
+	Event       int    `json:"event"`
+	Order       int    `json:"order"`
+	HasHeader   bool   `json:"hasHeader"`
+	Delimiter   string `json:"delimiter"` // "," or ";", detected when empty
+}
+
+// noCSVColumns is a mapping with every column absent
+func noCSVColumns() CSVColumnMapping {
+	return CSVColumnMapping{Bib: -1, FirstName: -1, LastName: -1, Name: -1, Affiliation: -1, Event: -1, Order: -1}
+}
+
+// UnmarshalJSON starts from noCSVColumns, so a field the caller left out is
+// absent rather than column 0
+func (m *CSVColumnMapping) UnmarshalJSON(data []byte) error {
+	type plain CSVColumnMapping
+	p := plain(noCSVColumns())
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*m = CSVColumnMapping(p)
+	return nil
+}
+
+// CSVPreview lets the user confirm or correct the guessed mapping
+type CSVPreview struct {
+	Headers []string         `json:"headers"`
+	Rows    [][]string       `json:"rows"` // First few data rows
+	Mapping CSVColumnMapping `json:"mapping"`
+}
+
+const csvPreviewRows = 5
+
+// csvHeaderAliases maps a field to the header names meet software uses for it
+var csvHeaderAliases = map[string][]string{
+	"bib":         {"bib", "bib no", "bibno", "number", "no", "competitor number", "id"},
+	"firstName":   {"first name", "firstname", "first", "given name", "forename"},
+	"lastName":    {"last name", "lastname", "last", "surname", "family name"},
+	"name":        {"name", "athlete", "athlete name", "competitor"},
+	"affiliation": {"club", "team", "affiliation", "school", "nation", "country"},
+	"event":       {"event", "event name", "discipline"},
+	"order":       {"order", "draw", "position", "lane", "seq"},
+}
+
+func loadStartLists() map[string]*StartList {
+	lists := make(map[string]*StartList)
+	if err := loadJSONConfig(startListsFile, &lists); err != nil {
+		log.Printf("Could not load start lists: %v", err)
+	}
+	return lists
+}
+
+// detectDelimiter prefers semicolons when the first line has more of them,
+// as European exports use ; with a decimal comma
+func detectDelimiter(data string) rune {
+	first, _, _ := strings.Cut(data, "\n")
+	if strings.Count(first, ";") > strings.Count(first, ",") {
+		return ';'
+	}
+	return ','
+}
+
+func readCSVRecords(data string, delimiter string) ([][]string, error) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(data, "\uFEFF")))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comma = detectDelimiter(data)
+	if delimiter != "" {
+		r.Comma = []rune(delimiter)[0]
+	}
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CSV: %w", err)
+	}
+	return records, nil
+}
+
+// guessCSVMapping matches header names against csvHeaderAliases
+func guessCSVMapping(headers []string) CSVColumnMapping {
+	m := noCSVColumns()
+	fields := map[string]*int{
+		"bib": &m.Bib, "firstName": &m.FirstName, "lastName": &m.LastName, "name": &m.Name,
+		"affiliation": &m.Affiliation, "event": &m.Event, "order": &m.Order,
+	}
+	for i, header := range headers {
+		h := strings.ToLower(strings.TrimSpace(header))
+		for field, aliases := range csvHeaderAliases {
+			for _, alias := range aliases {
+				if h == alias && *fields[field] == -1 {
+					*fields[field] = i
+				}
+			}
+		}
+	}
+	m.HasHeader = m.Bib != -1 || m.LastName != -1 || m.Name != -1
+	return m
+}
+
+// cell returns a trimmed column value, or "" when the column is unmapped or missing
+func cell(record []string, column int) string {
+	if column < 0 || column >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[column])
+}
+
+// splitName splits "Last, First" or "First Last"
+func splitName(name string) (first, last string) {
+	if l, f, ok := strings.Cut(name, ","); ok {
+		return strings.TrimSpace(f), strings.TrimSpace(l)
+	}
+	if i := strings.LastIndex(name, " "); i > 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// parseCSVStartList groups rows by the event column, or files them all under
+// defaultEvent
+func parseCSVStartList(data string, m CSVColumnMapping, defaultEvent string) ([]StartList, error) {
+	if m.Bib < 0 {
+		return nil, fmt.Errorf("a bib column must be mapped")
+	}
+	records, err := readCSVRecords(data, m.Delimiter)
+	if err != nil {
+		return nil, err
+	}
+	if m.HasHeader && len(records) > 0 {
+		records = records[1:]
+	}
+	byEvent := make(map[string]*StartList)
+	var order []string
+	for _, record := range records {
+		entry := StartListEntry{
+			Bib:         cell(record, m.Bib),
+			FirstName:   cell(record, m.FirstName),
+			LastName:    cell(record, m.LastName),
+			Affiliation: cell(record, m.Affiliation),
+		}
+		if entry.Bib == "" {
+			continue
+		}
+		if entry.FirstName == "" && entry.LastName == "" {
+			entry.FirstName, entry.LastName = splitName(cell(record, m.Name))
+		}
+		entry.Order, _ = strconv.Atoi(cell(record, m.Order))
+		event := cell(record, m.Event)
+		if event == "" {
+			event = defaultEvent
+		}
+		if event == "" {
+			return nil, fmt.Errorf("no event column mapped and no event name given")
+		}
+		list, ok := byEvent[event]
+		if !ok {
+			list = &StartList{Event: event, Source: "csv"}
+			byEvent[event] = list
+			order = append(order, event)
+		}
+		list.Entries = append(list.Entries, entry)
+	}
+	lists := make([]StartList, 0, len(order))
+	for _, event := range order {
+		lists = append(lists, *byEvent[event])
+	}
+	return lists, nil
+}
+
+// parseLynxStartList reads FinishLynx/FieldLynx style .evt and .lff files: a
+// header line "event,round,heat/flight,name" is followed by entry lines
+// ",bib,order,last,first,affiliation". Lines starting with ; are comments.
+func parseLynxStartList(data string) ([]StartList, error) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(data, "\uFEFF")))
+	r.FieldsPerRecord = -1
+	r.Comment = ';'
+	var lists []StartList
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not parse Lynx file: %w", err)
+		}
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if strings.TrimSpace(record[0]) != "" {
+			event := cell(record, 3)
+			if event == "" {
+				event = "Event " + cell(record, 0)
+			}
+			if round := cell(record, 1); round != "" && round != "1" {
+				event += " R" + round
+			}
+			if flight := cell(record, 2); flight != "" && flight != "1" && flight != "0" {
+				event += " F" + flight
+			}
+			lists = append(lists, StartList{
+				Event:       event,
+				Source:      "lynx",
+				EventNumber: cell(record, 0),
+				Round:       cell(record, 1),
+				Flight:      cell(record, 2),
+			})
+			continue
+		}
+		if len(lists) == 0 {
+			return nil, fmt.Errorf("entry line before any event header")
+		}
+		entry := StartListEntry{
+			Bib:         cell(record, 1),
+			LastName:    cell(record, 3),
+			FirstName:   cell(record, 4),
+			Affiliation: cell(record, 5),
+		}
+		entry.Order, _ = strconv.Atoi(cell(record, 2))
+		if entry.Bib != "" {
+			current := &lists[len(lists)-1]
+			current.Entries = append(current.Entries, entry)
+		}
+	}
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("no events found")
+	}
+	return lists, nil
+}
+
+// parseJSONStartList accepts OpenTrack/Roster-style exports: either
+// {"events":[{"name":..,"entries":[..]}]}, a bare list of such events, or a
+// bare list of entries (filed under defaultEvent). Field names vary between
+// systems so several spellings are recognised.
+func parseJSONStartList(data string, defaultEvent string) ([]StartList, error) {
+	var root interface{}
+	if err := json.Unmarshal([]byte(data), &root); err != nil {
+		return nil, fmt.Errorf("could not parse JSON: %w", err)
+	}
+	if obj, ok := root.(map[string]interface{}); ok {
+		if events, ok := pickValue(obj, "events", "competitions", "startlists").([]interface{}); ok {
+			root = events
+		} else {
+			root = []interface{}{obj}
+		}
+	}
+	items, ok := root.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of events or entries")
+	}
+
+	var lists []StartList
+	var loose []StartListEntry
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if entries, ok := pickValue(obj, "entries", "athletes", "competitors", "startlist").([]interface{}); ok {
+			list := StartList{Event: pickString(obj, "name", "event", "eventName", "title"), Source: "json"}
+			if list.Event == "" {
+				list.Event = defaultEvent
+			}
+			for _, e := range entries {
+				if entryObj, ok := e.(map[string]interface{}); ok {
+					if entry, ok := jsonStartListEntry(entryObj); ok {
+						list.Entries = append(list.Entries, entry)
+					}
+				}
+			}
+			lists = append(lists, list)
+			continue
+		}
+		if entry, ok := jsonStartListEntry(obj); ok {
+			loose = append(loose, entry)
+		}
+	}
+	if len(loose) > 0 {
+		if defaultEvent == "" {
+			return nil, fmt.Errorf("entries have no event and no event name was given")
+		}
+		lists = append(lists, StartList{Event: defaultEvent, Source: "json", Entries: loose})
+	}
+	for _, list := range lists {
+		if list.Event == "" {
+			return nil, fmt.Errorf("an event has no name and no event name was given")
+		}
+	}
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("no entries found")
+	}
+	return lists, nil
+}
+
+func jsonStartListEntry(obj map[string]interface{}) (StartListEntry, bool) {
+	entry := StartListEntry{
+		Bib:         pickString(obj, "bib", "bibNumber", "competitorNumber", "number"),
+		FirstName:   pickString(obj, "firstName", "first_name", "givenName", "given_name", "forename"),
+		LastName:    pickString(obj, "lastName", "last_name", "familyName", "family_name", "surname"),
+		Affiliation: pickString(obj, "club", "team", "affiliation", "teamName", "nation"),
+	}
+	if entry.FirstName == "" && entry.LastName == "" {
+		entry.FirstName, entry.LastName = splitName(pickString(obj, "name", "athlete", "fullName"))
+	}
+	entry.Order, _ = strconv.Atoi(pickString(obj, "order", "startOrder", "draw", "position"))
+	return entry, entry.Bib != ""
+}
+
+// pickValue returns the first key present, matched case-insensitively
+func pickValue(obj map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		for k, v := range obj {
+			if strings.EqualFold(k, key) {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// pickString is pickValue for text, accepting numbers such as numeric bibs
+func pickString(obj map[string]interface{}, keys ...string) string {
+	switch v := pickValue(obj, keys...).(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// lookupEntryLocked finds a bib in the station's event, or in any start list
+// when the station has no event. Caller holds stateMux.
+func (a *App) lookupEntryLocked(devType, bib string) (StartListEntry, bool) {
+	event := ""
+	if st, ok := a.findStationLocked(devType); ok {
+		event = st.event
+	}
+	if list, ok := a.startLists[event]; ok {
+		for _, entry := range list.Entries {
+			if entry.Bib == bib {
+				return entry, true
+			}
+		}
+		return StartListEntry{}, false
+	}
+	for _, list := range a.startLists {
+		for _, entry := range list.Entries {
+			if entry.Bib == bib {
+				return entry, true
+			}
+		}
+	}
+	return StartListEntry{}, false
+}
+
+// storeStartLists replaces same-named events and saves them
+func (a *App) storeStartLists(lists []StartList) ([]StartList, error) {
+	now := time.Now().UTC()
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	for i := range lists {
+		lists[i].ImportedAt = now
+		sort.SliceStable(lists[i].Entries, func(x, y int) bool {
+			ex, ey := lists[i].Entries[x], lists[i].Entries[y]
+			return ex.Order != 0 && (ey.Order == 0 || ex.Order < ey.Order)
+		})
+		list := lists[i]
+		a.startLists[list.Event] = &list
+		log.Printf("Imported start list for %s: %d athletes (%s)", list.Event, len(list.Entries), list.Source)
+	}
+	if err := saveJSONConfig(startListsFile, a.startLists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// --- Wails Bindable Start List Functions ---
+
+// PreviewCSVStartList parses the header and first rows and guesses which
+// column is which, for the user to confirm before importing
+func (a *App) PreviewCSVStartList(data string) (*CSVPreview, error) {
+	records, err := readCSVRecords(data, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	preview := &CSVPreview{Headers: records[0], Mapping: guessCSVMapping(records[0])}
+	preview.Mapping.Delimiter = string(detectDelimiter(data))
+	rows := records
+	if preview.Mapping.HasHeader {
+		rows = records[1:]
+	}
+	if len(rows) > csvPreviewRows {
+		rows = rows[:csvPreviewRows]
+	}
+	preview.Rows = rows
+	return preview, nil
+}
+
+// ImportCSVStartList imports a CSV with the confirmed column mapping. event
+// names the event when the file has no event column.
+func (a *App) ImportCSVStartList(data string, mapping CSVColumnMapping, event string) ([]StartList, error) {
+	lists, err := parseCSVStartList(data, mapping, event)
+	if err != nil {
+		return nil, err
+	}
+	return a.storeStartLists(lists)
+}
+
+// ImportLynxStartList imports a FinishLynx/FieldLynx .evt or .lff file
+func (a *App) ImportLynxStartList(data string) ([]StartList, error) {
+	lists, err := parseLynxStartList(data)
+	if err != nil {
+		return nil, err
+	}
+	return a.storeStartLists(lists)
+}
+
+// ImportJSONStartList imports an OpenTrack/Roster-style JSON export
+func (a *App) ImportJSONStartList(data string, event string) ([]StartList, error) {
+	lists, err := parseJSONStartList(data, event)
+	if err != nil {
+		return nil, err
+	}
+	return a.storeStartLists(lists)
+}
+
+func (a *App) ListStartLists() []StartList {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	lists := make([]StartList, 0, len(a.startLists))
+	for _, list := range a.startLists {
+		lists = append(lists, *list)
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Event < lists[j].Event })
+	return lists
+}
+
+func (a *App) DeleteStartList(event string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if _, ok := a.startLists[event]; !ok {
+		return fmt.Errorf("no start list for '%s'", event)
+	}
+	delete(a.startLists, event)
+	return saveJSONConfig(startListsFile, a.startLists)
+}
+
+// SetStationEvent selects which imported event a station is measuring. Bibs
+// called at the station are looked up in that event's start list.
+func (a *App) SetStationEvent(devType, event string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	if _, ok := a.startLists[event]; !ok && event != "" {
+		return fmt.Errorf("no start list for '%s'", event)
+	}
+	st := a.stationLocked(devType)
+	if st.session != nil && st.session.Event != event {
+		return fmt.Errorf("session '%s' on %s is for event '%s' - end it before changing event",
+			st.session.SessionID, devType, st.session.Event)
+	}
+	st.event = event
+	return nil
+}
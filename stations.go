@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A station is one EDM and the event it is measuring. Several can run in one
+// instance (e.g. "edm-discus" and "edm-hammer"), each with its own
+// calibration in CalibrationStore, session, current athlete and scoreboard.
+// The device type doubles as the station name.
+
+const defaultScoreboardDevice = "scoreboard"
+
+type station struct {
+	session       *ThrowSession
+	scoreboard    string          // Scoreboard device this station drives, "" for none
+	event         string          // Start list event being measured, "" for none
+	implement     string          // Training implement for the next throws
+	tags          []string        // Training drill tags for the next throws
+	rosterIndex   int             // Current athlete in the training session's roster
+	board         ScoreboardState // Current athlete and what the board shows
+	stopPageCycle context.CancelFunc
+}
+
+// StationInfo summarises a station for the UI
+type StationInfo struct {
+	DeviceType  string          `json:"deviceType"`
+	Connected   bool            `json:"connected"`
+	CircleType  string          `json:"circleType"`
+	IsCentreSet bool            `json:"isCentreSet"`
+	Scoreboard  string          `json:"scoreboard"`
+	Event       string          `json:"event,omitempty"`
+	SessionID   string          `json:"sessionId,omitempty"`
+	Board       ScoreboardState `json:"board"`
+}
+
+func isEDMDevice(devType string) bool {
+	return devType == "edm" || strings.HasPrefix(devType, "edm-")
+}
+
+func isScoreboardDevice(devType string) bool {
+	return devType == defaultScoreboardDevice || strings.HasPrefix(devType, defaultScoreboardDevice+"-")
+}
+
+// newStation is a station's initial state
+func newStation() *station {
+	return &station{scoreboard: defaultScoreboardDevice}
+}
+
+// stationLocked returns the station for an EDM, creating it on first use.
+// Only paths that configure or measure at a station should call it, so
+// mistyped names are not listed as stations. Caller holds stateMux.
+func (a *App) stationLocked(devType string) *station {
+	st, ok := a.stations[devType]
+	if !ok {
+		st = newStation()
+		a.stations[devType] = st
+	}
+	return st
+}
+
+// findStationLocked returns the station for an EDM if it has been set up,
+// for read-only paths. Caller holds stateMux.
+func (a *App) findStationLocked(devType string) (*station, bool) {
+	st, ok := a.stations[devType]
+	return st, ok
+}
+
+// training reports whether the station is running a training session
+func (st *station) training() bool {
+	return st.session != nil && st.session.Mode == SessionTraining
+}
+
+// --- Wails Bindable Station Functions ---
+
+// ListStations returns every EDM that is connected, calibrated or in use
+func (a *App) ListStations() []StationInfo {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	names := make(map[string]bool)
+	for devType := range a.devices {
+		if isEDMDevice(devType) {
+			names[devType] = true
+		}
+	}
+	for devType := range a.CalibrationStore {
+		names[devType] = true
+	}
+	for devType := range a.stations {
+		names[devType] = true
+	}
+
+	infos := make([]StationInfo, 0, len(names))
+	for devType := range names {
+		st, ok := a.findStationLocked(devType)
+		if !ok {
+			st = newStation() // Connected or calibrated but not yet used
+		}
+		info := StationInfo{DeviceType: devType, Scoreboard: st.scoreboard, Event: st.event, Board: st.board}
+		if dev, ok := a.devices[devType]; ok {
+			info.Connected = dev.state == DeviceStateConnected
+		}
+		if cal, ok := a.CalibrationStore[devType]; ok {
+			info.CircleType = cal.SelectedCircleType
+			info.IsCentreSet = cal.IsCentreSet
+		}
+		if st.session != nil {
+			info.SessionID = st.session.SessionID
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].DeviceType < infos[j].DeviceType })
+	return infos
+}
+
+// SetStationScoreboard routes a station's marks to a scoreboard device such
+// as "scoreboard-discus". An empty name stops it driving any board.
+func (a *App) SetStationScoreboard(devType, scoreboard string) error {
+	if scoreboard != "" && !isScoreboardDevice(scoreboard) {
+		return fmt.Errorf("'%s' is not a scoreboard device", scoreboard)
+	}
+	a.stateMux.Lock()
+	st := a.stationLocked(devType)
+	if st.stopPageCycle != nil {
+		st.stopPageCycle()
+		st.stopPageCycle = nil
+	}
+	st.scoreboard = scoreboard
+	a.stateMux.Unlock()
+	return a.refreshScoreboard(devType)
+}
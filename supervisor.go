@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"sort"
+	"time"
+)
+
+// Device connection states reported to the frontend
+const (
+	DeviceStateConnected    = "connected"
+	DeviceStateReconnecting = "reconnecting"
+	DeviceStateDisconnected = "disconnected"
+)
+
+const (
+	reconnectBackoffMin    = 1 * time.Second
+	reconnectBackoffMax    = 30 * time.Second
+	windHeartbeatTimeout   = 10 * time.Second
+	supervisorPollInterval = 2 * time.Second
+)
+
+var errHeartbeatTimeout = errors.New("no data received within heartbeat timeout")
+
+// DeviceStatus is the per-device health snapshot sent to the UI
+type DeviceStatus struct {
+	DeviceType     string    `json:"deviceType"`
+	ConnectionType string    `json:"connectionType"`
+	Address        string    `json:"address"`
+	State          string    `json:"state"`
+	LastError      string    `json:"lastError,omitempty"`
+	LastActivity   time.Time `json:"lastActivity"`
+	Reconnects     int       `json:"reconnects"`
+}
+
+// deviceStatusLocked builds the status snapshot for a device. Caller holds stateMux.
+func deviceStatusLocked(devType string, dev *Device) DeviceStatus {
+	return DeviceStatus{
+		DeviceType:     devType,
+		ConnectionType: dev.ConnectionType,
+		Address:        dev.Address,
+		State:          dev.state,
+		LastError:      dev.lastError,
+		LastActivity:   dev.lastActivity,
+		Reconnects:     dev.reconnects,
+	}
+}
+
+// setDeviceStateLocked records a state change and notifies the frontend.
+// Caller holds stateMux.
+func (a *App) setDeviceStateLocked(devType string, dev *Device, state string) {
+	if dev.state == state {
+		return
+	}
+	dev.state = state
+	log.Printf("Device %s is now %s", devType, state)
+	a.emitEventLocked(EventDeviceState, deviceStatusLocked(devType, dev))
+}
+
+// reportDeviceFailure is called by any code path that sees a write error,
+// read EOF or missed heartbeat. It starts a single reconnect loop per device.
+func (a *App) reportDeviceFailure(devType string, err error) {
+	a.stateMux.Lock()
+	defer a.unlockState()
+	dev, ok := a.devices[devType]
+	if !ok || dev.state == DeviceStateReconnecting {
+		return
+	}
+	log.Printf("Device %s failed: %v", devType, err)
+	if err != nil {
+		dev.lastError = err.Error()
+	}
+	if dev.cancelListener != nil {
+		dev.cancelListener()
+	}
+	dev.io.stop()
+	if dev.Conn != nil {
+		dev.Conn.Close()
+	}
+	a.setDeviceStateLocked(devType, dev, DeviceStateReconnecting)
+	go a.reconnectDevice(devType, dev)
+}
+
+// isConnectionFailure separates a dead link from a slow instrument: read
+// timeouts are expected (e.g. no prism in view) and, like a cancelled
+// measurement, do not trigger a reconnect
+func isConnectionFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+	return true
+}
+
+// reconnectDevice retries the original Address with exponential backoff until
+// it succeeds or the device is disconnected or replaced by the user
+func (a *App) reconnectDevice(devType string, dev *Device) {
+	backoff := reconnectBackoffMin
+	for {
+		time.Sleep(backoff)
+
+		a.stateMux.Lock()
+		if a.devices[devType] != dev {
+			a.stateMux.Unlock()
+			return
+		}
+		connectionType, address, settings := dev.ConnectionType, dev.Address, dev.serialSettings
+		binding, bound := a.portBindings[devType]
+		bound = bound && dev.usbSerialNumber != ""
+		a.stateMux.Unlock()
+
+		// A replugged USB adapter may come back under a different port name
+		if bound {
+			if portName, err := resolveBoundPort(binding); err == nil {
+				address = portName
+			}
+		}
+		conn, err := openDeviceConn(connectionType, address, settings)
+
+		a.stateMux.Lock()
+		if a.devices[devType] != dev {
+			a.stateMux.Unlock()
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err != nil {
+			dev.lastError = err.Error()
+			a.stateMux.Unlock()
+			log.Printf("Reconnect to %s on %s failed, retrying in %v: %v", devType, address, backoff, err)
+			if backoff *= 2; backoff > reconnectBackoffMax {
+				backoff = reconnectBackoffMax
+			}
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		dev.Conn = conn
+		dev.io = newDeviceIO(conn)
+		dev.Address = address
+		if bound {
+			a.recordBoundPortLocked(devType, address)
+		}
+		dev.cancelListener = cancel
+		dev.lastActivity = time.Now()
+		dev.reconnects++
+		a.setDeviceStateLocked(devType, dev, DeviceStateConnected)
+		if devType == "wind" {
+			go a.StartWindListener(devType, ctx)
+		}
+		if isScoreboardDevice(devType) {
+			a.initialiseScoreboardLocked(devType)
+		}
+		a.unlockState()
+		log.Printf("Reconnected %s on %s", devType, address)
+		return
+	}
+}
+
+// superviseDevices watches for streaming devices that have gone quiet
+func (a *App) superviseDevices() {
+	ticker := time.NewTicker(supervisorPollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		var stale []string
+		a.stateMux.Lock()
+		for devType, dev := range a.devices {
+			if devType == "wind" && dev.state == DeviceStateConnected &&
+				time.Since(dev.lastActivity) > windHeartbeatTimeout {
+				stale = append(stale, devType)
+			}
+		}
+		a.stateMux.Unlock()
+		for _, devType := range stale {
+			a.reportDeviceFailure(devType, errHeartbeatTimeout)
+		}
+	}
+}
+
+// GetDeviceStatuses returns the health of every connected device
+func (a *App) GetDeviceStatuses() []DeviceStatus {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	statuses := make([]DeviceStatus, 0, len(a.devices))
+	for devType, dev := range a.devices {
+		statuses = append(statuses, deviceStatusLocked(devType, dev))
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].DeviceType < statuses[j].DeviceType })
+	return statuses
+}
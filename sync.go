@@ -0,0 +1,606 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	syncConfigFile   = "sync.json"
+	syncOutboxFile   = "sync_outbox.json"
+	syncCentralFile  = "sync_central.json"
+	syncInterval     = 2 * time.Second
+	syncBackoffMax   = 30 * time.Second
+	syncPushTimeout  = 10 * time.Second
+	syncMaxBatchSize = 500                    // Records per kind per push
+	syncOutboxSave   = 500 * time.Millisecond // Coalesces outbox writes while records arrive
+)
+
+// Sync roles
+const (
+	SyncRoleStation = "station" // Forwards its results to the central instance
+	SyncRoleCentral = "central" // Receives results on POST /api/sync
+)
+
+// SyncConfig controls replication to a central results PC. Stations need
+// CentralURL and the central instance's API token.
+type SyncConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Role         string `json:"role"`
+	InstanceName string `json:"instanceName"` // Identifies this instance's records, defaults to the hostname
+	CentralURL   string `json:"centralUrl"`   // e.g. http://192.168.1.10:8765
+	Token        string `json:"token"`
+}
+
+// SyncBatch is the replication payload. Records are immutable and carry an
+// ID, so the central instance can merge any number of retries and any mix of
+// stations without conflicts.
+type SyncBatch struct {
+	Origin       string               `json:"origin"`
+	Marks        []ThrowCoordinate    `json:"marks,omitempty"`
+	Attempts     []AttemptRecord      `json:"attempts,omitempty"`
+	Sessions     []ThrowSession       `json:"sessions,omitempty"`
+	Calibrations []EDMCalibrationData `json:"calibrations,omitempty"`
+	Wind         []WindSampleEvent    `json:"wind,omitempty"`
+}
+
+func (b *SyncBatch) size() int {
+	return len(b.Marks) + len(b.Attempts) + len(b.Sessions) + len(b.Calibrations) + len(b.Wind)
+}
+
+// SyncAck reports how many records of a batch were new to the central instance
+type SyncAck struct {
+	Accepted  int `json:"accepted"`
+	Duplicate int `json:"duplicate"`
+}
+
+// SyncPeer is a station as seen by the central instance
+type SyncPeer struct {
+	Origin   string    `json:"origin"`
+	LastSeen time.Time `json:"lastSeen"`
+	Marks    int       `json:"marks"`
+	Attempts int       `json:"attempts"`
+}
+
+type SyncStatus struct {
+	Enabled    bool       `json:"enabled"`
+	Role       string     `json:"role"`
+	Origin     string     `json:"origin"`
+	Pending    int        `json:"pending"` // Records waiting in the outbox
+	LastSentAt time.Time  `json:"lastSentAt"`
+	LastError  string     `json:"lastError,omitempty"`
+	Peers      []SyncPeer `json:"peers,omitempty"`
+}
+
+// SyncedResults is everything the central instance has merged apart from
+// marks and attempts, which join the normal throw list and attempts
+type SyncedResults struct {
+	Sessions     []ThrowSession                `json:"sessions"`
+	Calibrations map[string]EDMCalibrationData `json:"calibrations"` // Keyed origin/device
+	Wind         []WindSampleEvent             `json:"wind"`
+}
+
+// syncer holds the outbox and link state. It has its own lock so records can
+// be queued while stateMux is held; stateMux is always taken first.
+type syncer struct {
+	pushing    sync.Mutex // One push at a time, so trimming matches what was sent
+	mu         sync.Mutex
+	config     SyncConfig
+	outbox     SyncBatch
+	saveTimer  *time.Timer // Pending outbox write, nil once saved
+	wake       chan struct{}
+	lastSentAt time.Time
+	lastError  string
+	peers      map[string]*SyncPeer
+}
+
+// syncStore is the central instance's merged state. Guarded by stateMux.
+// It is saved before a batch is acknowledged, so a station never drops
+// records the central instance could still lose.
+type syncStore struct {
+	seen         map[string]bool
+	marks        []ThrowCoordinate
+	attempts     []AttemptRecord
+	sessions     map[string]*ThrowSession
+	calibrations map[string]EDMCalibrationData
+	wind         []WindSampleEvent
+}
+
+// syncStoreFile is the saved form of syncStore
+type syncStoreFile struct {
+	Seen         []string                      `json:"seen"`
+	Marks        []ThrowCoordinate             `json:"marks"`
+	Attempts     []AttemptRecord               `json:"attempts"`
+	Sessions     map[string]*ThrowSession      `json:"sessions"`
+	Calibrations map[string]EDMCalibrationData `json:"calibrations"`
+	Wind         []WindSampleEvent             `json:"wind"`
+}
+
+func newSyncer() *syncer {
+	s := &syncer{
+		wake:  make(chan struct{}, 1),
+		peers: make(map[string]*SyncPeer),
+	}
+	if err := loadJSONConfig(syncConfigFile, &s.config); err != nil {
+		log.Printf("Could not load sync config: %v", err)
+	}
+	if err := loadJSONConfig(syncOutboxFile, &s.outbox); err != nil {
+		log.Printf("Could not load sync outbox: %v", err)
+	}
+	return s
+}
+
+func newSyncStore() *syncStore {
+	return &syncStore{
+		seen:         make(map[string]bool),
+		sessions:     make(map[string]*ThrowSession),
+		calibrations: make(map[string]EDMCalibrationData),
+	}
+}
+
+func loadSyncStore() *syncStore {
+	s := newSyncStore()
+	var saved syncStoreFile
+	if err := loadJSONConfig(syncCentralFile, &saved); err != nil {
+		log.Printf("Could not load synced results: %v", err)
+		return s
+	}
+	for _, key := range saved.Seen {
+		s.seen[key] = true
+	}
+	s.marks = saved.Marks
+	s.attempts = saved.Attempts
+	s.wind = saved.Wind
+	for key, session := range saved.Sessions {
+		s.sessions[key] = session
+	}
+	for key, cal := range saved.Calibrations {
+		s.calibrations[key] = cal
+	}
+	return s
+}
+
+// save writes the merged state to disk. Caller holds stateMux.
+func (s *syncStore) save() error {
+	saved := syncStoreFile{
+		Seen:         make([]string, 0, len(s.seen)),
+		Marks:        s.marks,
+		Attempts:     s.attempts,
+		Sessions:     s.sessions,
+		Calibrations: s.calibrations,
+		Wind:         s.wind,
+	}
+	for key := range s.seen {
+		saved.Seen = append(saved.Seen, key)
+	}
+	sort.Strings(saved.Seen)
+	return saveJSONConfig(syncCentralFile, saved)
+}
+
+// clear drops the merged marks, attempts and wind but remembers their IDs,
+// so a station retrying an old batch does not bring them back. Caller holds
+// stateMux.
+func (s *syncStore) clear() {
+	if len(s.seen) == 0 {
+		return
+	}
+	s.marks = nil
+	s.attempts = nil
+	s.wind = nil
+	if err := s.save(); err != nil {
+		log.Printf("Could not save synced results: %v", err)
+	}
+}
+
+// newRecordID returns a random ID for a mark or attempt
+func newRecordID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// origin names this instance in replicated records
+func (s *syncer) origin() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.originLocked()
+}
+
+func (s *syncer) originLocked() string {
+	if s.config.InstanceName != "" {
+		return s.config.InstanceName
+	}
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return "polyfield"
+}
+
+// enqueue adds records to the outbox if this instance forwards to a central
+// one. The outbox is saved shortly afterwards, once for a burst of records,
+// so nothing is lost if the app closes offline.
+func (s *syncer) enqueue(add func(b *SyncBatch)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.config.Enabled || s.config.Role != SyncRoleStation {
+		return
+	}
+	add(&s.outbox)
+	if s.saveTimer == nil {
+		s.saveTimer = time.AfterFunc(syncOutboxSave, s.flushOutbox)
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flushOutbox writes the outbox now if a save is pending
+func (s *syncer) flushOutbox() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.saveTimer != nil {
+		s.saveOutboxLocked()
+	}
+}
+
+// saveOutboxLocked writes the outbox and cancels any pending save. Caller
+// holds s.mu.
+func (s *syncer) saveOutboxLocked() {
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+		s.saveTimer = nil
+	}
+	if err := saveJSONConfig(syncOutboxFile, s.outbox); err != nil {
+		log.Printf("Could not save sync outbox: %v", err)
+	}
+}
+
+// runSync pushes the outbox to the central instance, backing off while the
+// link is down
+func (a *App) runSync() {
+	delay := syncInterval
+	for {
+		select {
+		case <-a.sync.wake:
+		case <-time.After(delay):
+		}
+		if err := a.pushOutbox(); err != nil {
+			if delay *= 2; delay > syncBackoffMax {
+				delay = syncBackoffMax
+			}
+			continue
+		}
+		delay = syncInterval
+	}
+}
+
+// pushOutbox sends up to syncMaxBatchSize records of each kind and removes
+// them from the outbox once the central instance has acknowledged them
+func (a *App) pushOutbox() error {
+	s := a.sync
+	s.pushing.Lock()
+	defer s.pushing.Unlock()
+	s.mu.Lock()
+	cfg := s.config
+	if !cfg.Enabled || cfg.Role != SyncRoleStation || s.outbox.size() == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	batch := SyncBatch{
+		Origin:       s.originLocked(),
+		Marks:        headOf(s.outbox.Marks),
+		Attempts:     headOf(s.outbox.Attempts),
+		Sessions:     headOf(s.outbox.Sessions),
+		Calibrations: headOf(s.outbox.Calibrations),
+		Wind:         headOf(s.outbox.Wind),
+	}
+	s.mu.Unlock()
+
+	ack, err := postSyncBatch(cfg, batch)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.lastError = err.Error()
+		log.Printf("Sync to %s failed, %d records held: %v", cfg.CentralURL, s.outbox.size(), err)
+		return err
+	}
+	s.outbox.Marks = s.outbox.Marks[len(batch.Marks):]
+	s.outbox.Attempts = s.outbox.Attempts[len(batch.Attempts):]
+	s.outbox.Sessions = s.outbox.Sessions[len(batch.Sessions):]
+	s.outbox.Calibrations = s.outbox.Calibrations[len(batch.Calibrations):]
+	s.outbox.Wind = s.outbox.Wind[len(batch.Wind):]
+	s.lastSentAt = time.Now()
+	s.lastError = ""
+	s.saveOutboxLocked()
+	log.Printf("Synced %d records to %s (%d new)", batch.size(), cfg.CentralURL, ack.Accepted)
+	if s.outbox.size() > 0 {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func headOf[T any](records []T) []T {
+	if len(records) > syncMaxBatchSize {
+		records = records[:syncMaxBatchSize]
+	}
+	return append([]T(nil), records...)
+}
+
+func postSyncBatch(cfg SyncConfig, batch SyncBatch) (*SyncAck, error) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(cfg.CentralURL, "/")+"/api/sync", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.Token)
+	client := &http.Client{Timeout: syncPushTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return nil, fmt.Errorf("central returned %s: %s", resp.Status, apiErr.Error)
+	}
+	var ack SyncAck
+	if err := json.NewDecoder(resp.Body).Decode(&ack); err != nil {
+		return nil, fmt.Errorf("invalid acknowledgement: %w", err)
+	}
+	return &ack, nil
+}
+
+// --- Central merge ---
+
+// mergeSyncBatch folds a station's batch into this instance. Marks and
+// attempts are a grow-only set keyed by ID, sessions are unioned by their
+// marks, and calibrations keep the newest per origin and device. The merged
+// state is saved before returning, and a failed save is an error so the
+// station keeps the batch and sends it again.
+func (a *App) mergeSyncBatch(batch SyncBatch) (*SyncAck, error) {
+	if batch.Origin == "" {
+		return nil, fmt.Errorf("batch has no origin")
+	}
+	a.sync.mu.Lock()
+	role := a.sync.config.Role
+	a.sync.mu.Unlock()
+	if role != SyncRoleCentral {
+		return nil, fmt.Errorf("this instance is not a central results instance")
+	}
+
+	var ack SyncAck
+	var newMarks []ThrowCoordinate
+	var newAttempts []AttemptRecord
+	a.stateMux.Lock()
+	store := a.synced
+	accept := func(key string) bool {
+		if store.seen[key] {
+			ack.Duplicate++
+			return false
+		}
+		store.seen[key] = true
+		ack.Accepted++
+		return true
+	}
+	for _, mark := range batch.Marks {
+		if mark.Origin == "" {
+			mark.Origin = batch.Origin
+		}
+		if mark.ID == "" || !accept("mark/"+mark.ID) {
+			continue
+		}
+		a.throwCoordinates = append(a.throwCoordinates, mark)
+		store.marks = append(store.marks, mark)
+		newMarks = append(newMarks, mark)
+	}
+	for _, attempt := range batch.Attempts {
+		if attempt.Origin == "" {
+			attempt.Origin = batch.Origin
+		}
+		if attempt.ID == "" || !accept("attempt/"+attempt.ID) {
+			continue
+		}
+		a.attempts = append(a.attempts, attempt)
+		store.attempts = append(store.attempts, attempt)
+		newAttempts = append(newAttempts, attempt)
+	}
+	for _, session := range batch.Sessions {
+		if session.Origin == "" {
+			session.Origin = batch.Origin
+		}
+		store.mergeSession(session)
+		ack.Accepted++
+	}
+	for _, cal := range batch.Calibrations {
+		key := batch.Origin + "/" + cal.DeviceID
+		if existing, ok := store.calibrations[key]; ok && cal.Timestamp.Before(existing.Timestamp) {
+			ack.Duplicate++
+			continue
+		}
+		store.calibrations[key] = cal
+		ack.Accepted++
+	}
+	for _, wind := range batch.Wind {
+		if wind.Origin == "" {
+			wind.Origin = batch.Origin
+		}
+		if !accept(fmt.Sprintf("wind/%s/%s/%d", wind.Origin, wind.DeviceType, wind.Timestamp.UnixNano())) {
+			continue
+		}
+		store.wind = append(store.wind, wind)
+		a.windSamples = append(a.windSamples, wind)
+	}
+	// Saved even when everything was a duplicate, in case an earlier save of
+	// the same records failed
+	saveErr := store.save()
+
+	a.sync.mu.Lock()
+	peer, ok := a.sync.peers[batch.Origin]
+	if !ok {
+		peer = &SyncPeer{Origin: batch.Origin}
+		a.sync.peers[batch.Origin] = peer
+	}
+	peer.LastSeen = time.Now()
+	peer.Marks += len(newMarks)
+	peer.Attempts += len(newAttempts)
+	a.sync.mu.Unlock()
+	a.stateMux.Unlock()
+
+	circles := make(map[string]bool)
+	for _, mark := range newMarks {
+		a.emitEvent(EventMarkRecorded, mark)
+		a.publishFeed(FeedMark, mark)
+		circles[mark.CircleType] = true
+	}
+	// Rankings on the central instance span every station of the event
+	for circleType := range circles {
+		a.stateMux.Lock()
+		standings := a.circleStandingsLocked(circleType)
+		a.stateMux.Unlock()
+		if len(standings) > 0 && a.feed.rankingChanged("*/"+circleType, standings) {
+			a.publishFeed(FeedRanking, RankingUpdate{CircleType: circleType, Standings: standings})
+		}
+	}
+	for _, attempt := range newAttempts {
+		a.publishFeed(attemptFeedKinds[attempt.Kind], attempt)
+	}
+	if saveErr != nil {
+		log.Printf("Could not save synced results from %s: %v", batch.Origin, saveErr)
+		return nil, fmt.Errorf("could not save synced results: %w", saveErr)
+	}
+	return &ack, nil
+}
+
+// mergeSession unions a session snapshot with any earlier one. Caller holds
+// stateMux.
+func (s *syncStore) mergeSession(session ThrowSession) {
+	key := fmt.Sprintf("%s/%s/%s/%d", session.Origin, session.Station, session.SessionID, session.StartTime.UnixNano())
+	existing, ok := s.sessions[key]
+	if !ok {
+		copied := session
+		copied.Coordinates = append([]ThrowCoordinate(nil), session.Coordinates...)
+		s.sessions[key] = &copied
+		updateSessionStatistics(&copied)
+		return
+	}
+	have := make(map[string]bool, len(existing.Coordinates))
+	for _, coord := range existing.Coordinates {
+		have[coord.ID] = true
+	}
+	for _, coord := range session.Coordinates {
+		if coord.ID == "" || !have[coord.ID] {
+			existing.Coordinates = append(existing.Coordinates, coord)
+		}
+	}
+	if existing.EndTime == nil {
+		existing.EndTime = session.EndTime
+	}
+	// Renames are sent as a new snapshot of the same session
+	existing.Name = session.Name
+	updateSessionStatistics(existing)
+}
+
+// --- Wails Bindable Sync Functions ---
+
+// SetSyncConfig saves the replication settings. Stations start forwarding
+// new results immediately; anything already in the outbox is kept.
+func (a *App) SetSyncConfig(cfg SyncConfig) error {
+	switch cfg.Role {
+	case SyncRoleStation:
+		if cfg.Enabled && cfg.CentralURL == "" {
+			return fmt.Errorf("central URL is required for a station")
+		}
+	case SyncRoleCentral:
+	default:
+		return fmt.Errorf("unknown sync role '%s'", cfg.Role)
+	}
+	a.sync.mu.Lock()
+	a.sync.config = cfg
+	a.sync.mu.Unlock()
+	return saveJSONConfig(syncConfigFile, cfg)
+}
+
+func (a *App) GetSyncConfig() SyncConfig {
+	a.sync.mu.Lock()
+	defer a.sync.mu.Unlock()
+	return a.sync.config
+}
+
+func (a *App) GetSyncStatus() SyncStatus {
+	a.sync.mu.Lock()
+	defer a.sync.mu.Unlock()
+	status := SyncStatus{
+		Enabled:    a.sync.config.Enabled,
+		Role:       a.sync.config.Role,
+		Origin:     a.sync.originLocked(),
+		Pending:    a.sync.outbox.size(),
+		LastSentAt: a.sync.lastSentAt,
+		LastError:  a.sync.lastError,
+	}
+	for _, peer := range a.sync.peers {
+		status.Peers = append(status.Peers, *peer)
+	}
+	sort.Slice(status.Peers, func(i, j int) bool { return status.Peers[i].Origin < status.Peers[j].Origin })
+	return status
+}
+
+// SyncNow pushes the outbox immediately instead of waiting for the next retry
+func (a *App) SyncNow() error {
+	cfg := a.GetSyncConfig()
+	if !cfg.Enabled || cfg.Role != SyncRoleStation {
+		return fmt.Errorf("sync is not enabled for a station")
+	}
+	return a.pushOutbox()
+}
+
+// GetSyncedResults returns the sessions, calibrations and wind readings
+// merged from stations
+func (a *App) GetSyncedResults() SyncedResults {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	results := SyncedResults{
+		Sessions:     make([]ThrowSession, 0, len(a.synced.sessions)),
+		Calibrations: make(map[string]EDMCalibrationData, len(a.synced.calibrations)),
+		Wind:         append([]WindSampleEvent(nil), a.synced.wind...),
+	}
+	for _, session := range a.synced.sessions {
+		results.Sessions = append(results.Sessions, *session)
+	}
+	sort.Slice(results.Sessions, func(i, j int) bool {
+		return results.Sessions[i].StartTime.Before(results.Sessions[j].StartTime)
+	})
+	for key, cal := range a.synced.calibrations {
+		results.Calibrations[key] = cal
+	}
+	return results
+}
+
+// queueSessionSync forwards a snapshot of a session. Caller holds stateMux.
+func (a *App) queueSessionSync(session *ThrowSession) {
+	snapshot := *cloneSession(session)
+	a.sync.enqueue(func(b *SyncBatch) { b.Sessions = append(b.Sessions, snapshot) })
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+)
+
+// Training sessions rotate through a roster of athletes, record the
+// implement and drill tags with each throw, and skip competition rules:
+// measuring does not need a verified edge, and throws stay out of standings
+// and results.
+
+const SessionTraining = "training"
+
+// trainingAttemptLocked is the athlete's next throw number in the session.
+// Caller holds stateMux.
+func trainingAttemptLocked(session *ThrowSession, bib string) int {
+	n := 1
+	for _, coord := range session.Coordinates {
+		if coord.AthleteID == bib {
+			n++
+		}
+	}
+	return n
+}
+
+// selectRosterAthleteLocked puts the roster athlete at index on the board.
+// Caller holds stateMux.
+func (a *App) selectRosterAthleteLocked(devType string, st *station, index int) {
+	st.rosterIndex = index
+	bib := st.session.Roster[index]
+	st.board.Bib = bib
+	st.board.Name = ""
+	if entry, ok := a.lookupEntryLocked(devType, bib); ok {
+		st.board.Name = entry.DisplayName()
+	}
+	st.board.Attempt = trainingAttemptLocked(st.session, bib)
+	st.board.Mark = ""
+	st.board.Position = 0
+}
+
+// --- Wails Bindable Training Functions ---
+
+// StartTrainingSession starts a training session with the athletes in
+// rotation order and the implement in use, ending any current session
+func (a *App) StartTrainingSession(devType string, circleType string, sessionID string, roster []string, implement string) error {
+	if err := a.StartThrowSession(devType, circleType, sessionID); err != nil {
+		return err
+	}
+	a.stateMux.Lock()
+	st := a.stationLocked(devType)
+	st.session.Mode = SessionTraining
+	st.session.Roster = slices.Clone(roster)
+	st.implement = implement
+	st.tags = nil
+	if len(roster) > 0 {
+		a.selectRosterAthleteLocked(devType, st, 0)
+	}
+	a.queueSessionSync(st.session)
+	a.stateMux.Unlock()
+
+	log.Printf("Training session on %s: %d athletes, implement '%s'", devType, len(roster), implement)
+	return a.refreshScoreboard(devType)
+}
+
+// NextTrainingAthlete moves to the next athlete in the roster, wrapping
+// round, and returns what the board now shows
+func (a *App) NextTrainingAthlete(devType string) (ScoreboardState, error) {
+	a.stateMux.Lock()
+	st, ok := a.findStationLocked(devType)
+	if !ok || !st.training() || len(st.session.Roster) == 0 {
+		a.stateMux.Unlock()
+		return ScoreboardState{}, fmt.Errorf("no training roster on %s", devType)
+	}
+	a.selectRosterAthleteLocked(devType, st, (st.rosterIndex+1)%len(st.session.Roster))
+	board := st.board
+	a.stateMux.Unlock()
+	return board, a.refreshScoreboard(devType)
+}
+
+// SetTrainingRoster replaces the rotation, e.g. when an athlete joins late.
+// The current athlete stays selected if still in it.
+func (a *App) SetTrainingRoster(devType string, roster []string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	st, ok := a.findStationLocked(devType)
+	if !ok || !st.training() {
+		return fmt.Errorf("no training session on %s", devType)
+	}
+	st.session.Roster = slices.Clone(roster)
+	st.rosterIndex = max(slices.Index(roster, st.board.Bib), 0)
+	a.queueSessionSync(st.session)
+	return nil
+}
+
+// SetTrainingImplement sets the implement recorded with the next throws,
+// e.g. "4kg" or "700g"
+func (a *App) SetTrainingImplement(devType string, implement string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	st, ok := a.findStationLocked(devType)
+	if !ok || !st.training() {
+		return fmt.Errorf("no training session on %s", devType)
+	}
+	st.implement = implement
+	return nil
+}
+
+// SetTrainingTags sets the drill tags recorded with the next throws
+func (a *App) SetTrainingTags(devType string, tags []string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	st, ok := a.findStationLocked(devType)
+	if !ok || !st.training() {
+		return fmt.Errorf("no training session on %s", devType)
+	}
+	st.tags = slices.Clone(tags)
+	return nil
+}
+
+// TagThrow replaces a recorded throw's tags, for notes added afterwards
+func (a *App) TagThrow(throwID string, tags []string) error {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+	found := false
+	for i := range a.throwCoordinates {
+		if a.throwCoordinates[i].ID == throwID {
+			a.throwCoordinates[i].Tags = slices.Clone(tags)
+			found = true
+		}
+	}
+	// Update the copy in the session holding the throw, running or archived
+	tagIn := func(session *ThrowSession) bool {
+		for i := range session.Coordinates {
+			if session.Coordinates[i].ID == throwID {
+				session.Coordinates[i].Tags = slices.Clone(tags)
+				a.queueSessionSync(session)
+				return true
+			}
+		}
+		return false
+	}
+	for _, st := range a.stations {
+		if st.session != nil {
+			tagIn(st.session)
+		}
+	}
+	archived := false
+	for _, session := range a.sessions {
+		archived = tagIn(session) || archived
+	}
+	if !found {
+		return fmt.Errorf("throw '%s' not found", throwID)
+	}
+	if archived {
+		if err := saveJSONConfig(sessionArchiveFile, a.sessions); err != nil {
+			return err
+		}
+	}
+	log.Printf("Tagged throw %s: %v", throwID, tags)
+	return nil
+}